@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"log"
+	"maps"
 	"sync"
 	"time"
 
@@ -11,16 +12,29 @@ import (
 
 // Checker manages and performs periodic health checks using registered Probes.
 type Checker struct {
-	opts                 checkerOptions
+	opts checkerOptions
+
+	// consecutiveSuccesses, consecutiveFailures and the
+	// fields below are guarded by statsMu.
 	consecutiveSuccesses int
 	consecutiveFailures  int
+	checks               uint64
+	lastCheck            time.Time
+	notifications        uint64
+	droppedUpdates       uint64
+	probeStats           map[string]probeStats
+	lastOverrides        map[string]Override
+	statsMu              sync.Mutex
 
 	probes    map[string]*probeConfig
-	reporters []Reporter
+	reporters []*reporterEntry
 	chMu      sync.RWMutex
 
 	watchers   []chan Status
 	watchersMu sync.Mutex
+
+	overrides   map[string]Override
+	overridesMu sync.Mutex
 }
 
 type probeConfig struct {
@@ -42,9 +56,11 @@ func NewChecker(o ...CheckerOption) (*Checker, error) {
 	}
 
 	return &Checker{
-		opts:      opts,
-		probes:    make(map[string]*probeConfig),
-		reporters: make([]Reporter, 0),
+		opts:       opts,
+		probeStats: make(map[string]probeStats),
+		probes:     make(map[string]*probeConfig),
+		reporters:  make([]*reporterEntry, 0),
+		overrides:  make(map[string]Override),
 	}, nil
 }
 
@@ -87,7 +103,7 @@ func (ch *Checker) AddReporter(reporter Reporter) *Checker {
 	ch.chMu.Lock()
 	defer ch.chMu.Unlock()
 
-	ch.reporters = append(ch.reporters, reporter)
+	ch.reporters = append(ch.reporters, newReporterEntry(reporter))
 
 	return ch
 }
@@ -130,7 +146,7 @@ func (ch *Checker) startChecking(ctx context.Context) {
 
 			return
 		case <-ticker.C:
-			st := NewStatus()
+			st := newStatus(time.Now())
 			wg := sync.WaitGroup{}
 			probes := ch.getProbes()
 
@@ -143,11 +159,12 @@ func (ch *Checker) startChecking(ctx context.Context) {
 					probeCtx, cancel := context.WithTimeout(ctx, pc.timeout)
 					defer cancel()
 
-					st.Append(pc.name, pc.probe.Check(probeCtx))
+					ch.appendResult(st, pc.name, pc.probe.Check(probeCtx))
 				}(probes[i])
 			}
 
 			wg.Wait()
+			ch.recordCheck(st)
 			ch.notifyStatus(st)
 		}
 	}
@@ -157,6 +174,9 @@ func (ch *Checker) notifyStatus(st Status) {
 	shouldNotify := false
 	hasFailed := st.AsError() != nil
 
+	ch.statsMu.Lock()
+	defer ch.statsMu.Unlock()
+
 	if hasFailed {
 		ch.consecutiveFailures++
 		ch.consecutiveSuccesses = 0
@@ -175,13 +195,24 @@ func (ch *Checker) notifyStatus(st Status) {
 		}
 	}
 
+	// Setting, clearing or expiring an override takes effect immediately,
+	// further statuses are subject to the thresholds as usual.
+	overrides := Overrides(st)
+	if !maps.Equal(overrides, ch.lastOverrides) {
+		shouldNotify = true
+	}
+
+	ch.lastOverrides = overrides
+
 	if shouldNotify {
+		ch.notifications++
 		ch.watchersMu.Lock()
 
 		for _, watcher := range ch.watchers {
 			select {
 			case watcher <- st:
 			default:
+				ch.droppedUpdates++
 			}
 		}
 
@@ -220,11 +251,16 @@ func (ch *Checker) startReporting(ctx context.Context) {
 			for _, reporter := range reporters {
 				wg.Add(1)
 
-				go func(r Reporter) {
+				go func(entry *reporterEntry) {
 					defer wg.Done()
 
 					rErr := backoff.Retry(
-						func() error { return r.Report(ctx, st) },
+						func() error {
+							err := entry.reporter.Report(ctx, st)
+							ch.recordAttempt(entry, err)
+
+							return err
+						},
 						backoff.WithContext(
 							backoff.NewExponentialBackOff(
 								backoff.WithMaxElapsedTime(ch.opts.reporterTimeout),
@@ -234,7 +270,8 @@ func (ch *Checker) startReporting(ctx context.Context) {
 					)
 
 					if rErr != nil {
-						log.Printf("health checker: reporter %T failed to report status: %s", r, rErr)
+						ch.recordFailure(entry)
+						log.Printf("health checker: reporter %T failed to report status: %s", entry.reporter, rErr)
 					}
 				}(reporter)
 			}
@@ -256,11 +293,11 @@ func (ch *Checker) getProbes() []*probeConfig {
 	return pbs
 }
 
-func (ch *Checker) getReporters() []Reporter {
+func (ch *Checker) getReporters() []*reporterEntry {
 	ch.chMu.RLock()
 	defer ch.chMu.RUnlock()
 
-	reporters := make([]Reporter, len(ch.reporters))
+	reporters := make([]*reporterEntry, len(ch.reporters))
 	copy(reporters, ch.reporters)
 
 	return reporters
@@ -3,6 +3,7 @@ package health_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -258,6 +259,108 @@ func TestHealth_Reporter_ReceivesStatusUpdates(t *testing.T) {
 	assert.NoError(t, mock.getLast().AsError())
 }
 
+func TestHealth_Stats(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	checker, err := health.NewChecker(health.WithPeriod(time.Second), health.WithBufferSize(1))
+	require.NoError(t, err)
+
+	checker.AddProbe("fail", health.ProbeFunc(func(context.Context) error { return errors.New("fail") }))
+	checker.AddReporter(&mockReporter{})
+
+	// The returned watcher is never read, so updates are dropped once its buffer is full.
+	checker.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return checker.Stats().DroppedUpdates > 0
+	}, 8*time.Second, 50*time.Millisecond)
+
+	stats := checker.Stats()
+	assert.Equal(t, 2, stats.Watchers)
+	assert.GreaterOrEqual(t, stats.ConsecutiveFailures, 3)
+	assert.GreaterOrEqual(t, stats.Notifications, uint64(2))
+	require.Len(t, stats.Probes, 1)
+	assert.EqualError(t, stats.Probes[0].LastError, "fail")
+	require.Len(t, stats.Reporters, 1)
+	assert.Positive(t, stats.Reporters[0].Reports)
+}
+
+func TestHealth_Overrides(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	checker, err := health.NewChecker(health.WithPeriod(50 * time.Millisecond))
+	require.NoError(t, err)
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error { return errors.New("down") }))
+	checker.AddProbe("cache", health.ProbeFunc(func(context.Context) error { return nil }))
+
+	require.Error(t, checker.SetOverride(health.Override{Probe: "db", Healthy: true, Until: time.Now().Add(time.Minute)}))
+	require.Error(t, checker.SetOverride(health.Override{Probe: "db", Healthy: true, Reason: "failover", Until: time.Now()}))
+	require.Error(t, checker.SetOverride(health.Override{Probe: "DB", Healthy: true, Reason: "failover", Until: time.Now().Add(time.Minute)}))
+	require.NoError(t, checker.SetOverride(health.Override{Probe: "db", Healthy: true, Reason: "failover", Until: time.Now().Add(time.Minute)}))
+
+	statusCh := checker.Start(ctx)
+
+	st := <-statusCh
+	require.NoError(t, st.AsError())
+	assert.Equal(t, "failover", health.Overrides(st)["db"].Reason)
+	assert.NotContains(t, health.Overrides(st), "cache")
+
+	require.NoError(t, checker.SetOverride(health.Override{Reason: "maintenance", Until: time.Now().Add(time.Minute)}))
+
+	overrides := checker.Overrides()
+	require.Len(t, overrides, 2)
+	assert.Empty(t, overrides[0].Probe)
+	assert.Equal(t, "db", overrides[1].Probe)
+
+	require.Eventually(t, func() bool {
+		st = <-statusCh
+
+		return st.Errors()["cache"] != nil
+	}, 2*time.Second, time.Millisecond)
+
+	var oErr *health.OverrideError
+	require.ErrorAs(t, st.Errors()["db"], &oErr)
+	assert.Equal(t, "maintenance", oErr.Override.Reason)
+
+	assert.True(t, checker.ClearOverride(""))
+	assert.False(t, checker.ClearOverride(""))
+	assert.Len(t, checker.Stats().Overrides, 1)
+}
+
+func TestHealth_OverridesKeepThresholds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	checker, err := health.NewChecker(
+		health.WithPeriod(50*time.Millisecond),
+		health.WithFailureThreshold(3),
+	)
+	require.NoError(t, err)
+
+	var attempts atomic.Int64
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error { return nil }))
+	checker.AddProbe("cache", health.ProbeFunc(func(context.Context) error {
+		return fmt.Errorf("attempt %d failed", attempts.Add(1))
+	}))
+
+	require.NoError(t, checker.SetOverride(health.Override{Probe: "db", Healthy: true, Reason: "failover", Until: time.Now().Add(time.Minute)}))
+
+	statusCh := checker.Start(ctx)
+
+	// the first status with the override applied bypasses the thresholds.
+	st := <-statusCh
+	require.EqualError(t, st.Errors()["cache"], "attempt 1 failed")
+
+	// the next ones are subject to them, even if the override is still active.
+	st = <-statusCh
+	require.EqualError(t, st.Errors()["cache"], "attempt 3 failed")
+	assert.Contains(t, health.Overrides(st), "db")
+}
+
 type mockReporter struct {
 	calls atomic.Int64
 
@@ -0,0 +1,205 @@
+// Package debug exposes the internal state of a health.Checker, as returned
+// by Checker.Stats, through expvar and an HTTP handler, similarly to the
+// net/http/pprof package:
+//
+//	debug.Publish("health", checker)
+//	http.Handle("/debug/health", debug.Handler(checker))
+//
+// The served state includes the raw errors of probes and reporters, which
+// may hold connection strings and host names. Like the pprof endpoints, it
+// must not be exposed publicly: serve it on an internal listener or behind
+// authentication, or use WithRedactedErrors.
+package debug
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// redacted replaces the errors served when WithRedactedErrors is used.
+const redacted = "[redacted]"
+
+// Snapshot is the JSON representation of health.Stats.
+type Snapshot struct {
+	Options              Options          `json:"options"`
+	ConsecutiveSuccesses int              `json:"consecutiveSuccesses"`
+	ConsecutiveFailures  int              `json:"consecutiveFailures"`
+	Checks               uint64           `json:"checks"`
+	LastCheck            *time.Time       `json:"lastCheck,omitempty"`
+	Notifications        uint64           `json:"notifications"`
+	Watchers             int              `json:"watchers"`
+	DroppedUpdates       uint64           `json:"droppedUpdates"`
+	Probes               map[string]Probe `json:"probes"`
+	Reporters            []Reporter       `json:"reporters"`
+	Overrides            []Override       `json:"overrides,omitempty"`
+}
+
+// Options are the scheduling and threshold options of the Checker.
+type Options struct {
+	Period           string `json:"period"`
+	InitialDelay     string `json:"initialDelay"`
+	SuccessThreshold int    `json:"successThreshold"`
+	FailureThreshold int    `json:"failureThreshold"`
+	ReporterTimeout  string `json:"reporterTimeout"`
+	BufferSize       int    `json:"bufferSize"`
+}
+
+// Probe is the state of a single probe.
+type Probe struct {
+	Timeout      string     `json:"timeout"`
+	LastCheck    *time.Time `json:"lastCheck,omitempty"`
+	Healthy      bool       `json:"healthy"`
+	LastError    string     `json:"lastError,omitempty"`
+	LastDuration string     `json:"lastDuration,omitempty"`
+}
+
+// Reporter is the state of a single reporter.
+type Reporter struct {
+	Type          string     `json:"type"`
+	Reports       uint64     `json:"reports"`
+	Failures      uint64     `json:"failures"`
+	Retrying      bool       `json:"retrying"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorTime *time.Time `json:"lastErrorTime,omitempty"`
+}
+
+// Override is an active manual override. An empty probe name means
+// the override applies to every probe.
+type Override struct {
+	Probe   string    `json:"probe,omitempty"`
+	Healthy bool      `json:"healthy"`
+	Reason  string    `json:"reason"`
+	Until   time.Time `json:"until"`
+}
+
+// NewSnapshot converts the given stats into their JSON representation.
+func NewSnapshot(st health.Stats) Snapshot {
+	out := Snapshot{
+		Options: Options{
+			Period:           st.Period.String(),
+			InitialDelay:     st.InitialDelay.String(),
+			SuccessThreshold: st.SuccessThreshold,
+			FailureThreshold: st.FailureThreshold,
+			ReporterTimeout:  st.ReporterTimeout.String(),
+			BufferSize:       st.BufferSize,
+		},
+		ConsecutiveSuccesses: st.ConsecutiveSuccesses,
+		ConsecutiveFailures:  st.ConsecutiveFailures,
+		Checks:               st.Checks,
+		LastCheck:            timePtr(st.LastCheck),
+		Notifications:        st.Notifications,
+		Watchers:             st.Watchers,
+		DroppedUpdates:       st.DroppedUpdates,
+		Probes:               make(map[string]Probe, len(st.Probes)),
+		Reporters:            make([]Reporter, 0, len(st.Reporters)),
+	}
+
+	for _, p := range st.Probes {
+		probe := Probe{
+			Timeout:   p.Timeout.String(),
+			LastCheck: timePtr(p.LastCheck),
+			Healthy:   !p.LastCheck.IsZero() && p.LastError == nil,
+		}
+
+		if !p.LastCheck.IsZero() {
+			probe.LastDuration = p.LastDuration.String()
+		}
+
+		if p.LastError != nil {
+			probe.LastError = p.LastError.Error()
+		}
+
+		out.Probes[p.Name] = probe
+	}
+
+	for _, r := range st.Reporters {
+		reporter := Reporter{
+			Type:          r.Type,
+			Reports:       r.Reports,
+			Failures:      r.Failures,
+			Retrying:      r.Retrying,
+			LastErrorTime: timePtr(r.LastErrorTime),
+		}
+
+		if r.LastError != nil {
+			reporter.LastError = r.LastError.Error()
+		}
+
+		out.Reporters = append(out.Reporters, reporter)
+	}
+
+	for _, o := range st.Overrides {
+		out.Overrides = append(out.Overrides, Override(o))
+	}
+
+	return out
+}
+
+// Publish publishes the state of the given Checker as an expvar variable
+// with the given name, served by the /debug/vars endpoint of the expvar
+// package. Like expvar.Publish, it panics if the name is already in use.
+//
+// The /debug/vars endpoint must not be exposed publicly, see the package
+// documentation.
+func Publish(name string, ch *health.Checker, o ...Option) {
+	opts := newOptions(o)
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return opts.snapshot(ch)
+	}))
+}
+
+// Handler returns an http.Handler which serves the state of the given
+// Checker as an indented JSON document.
+//
+// The handler does not authenticate requests and must not be exposed
+// publicly, see the package documentation.
+func Handler(ch *health.Checker, o ...Option) http.Handler {
+	opts := newOptions(o)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		_ = enc.Encode(opts.snapshot(ch))
+	})
+}
+
+// snapshot returns the snapshot of the given Checker, redacting the errors
+// if requested.
+func (o *options) snapshot(ch *health.Checker) Snapshot {
+	out := NewSnapshot(ch.Stats())
+	if !o.redactErrors {
+		return out
+	}
+
+	for name, p := range out.Probes {
+		if p.LastError != "" {
+			p.LastError = redacted
+			out.Probes[name] = p
+		}
+	}
+
+	for i := range out.Reporters {
+		if out.Reporters[i].LastError != "" {
+			out.Reporters[i].LastError = redacted
+		}
+	}
+
+	return out
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,118 @@
+package debug_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/debug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	checker, err := health.NewChecker(
+		health.WithPeriod(time.Second),
+		health.WithFailureThreshold(2),
+		health.WithReporterTimeout(time.Second),
+	)
+	require.NoError(t, err)
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error { return errors.New("connection refused") }))
+	checker.AddProbe("cache", health.ProbeFunc(func(context.Context) error { return nil }))
+
+	var attempts atomic.Int64
+
+	checker.AddReporter(health.ReporterFunc(func(context.Context, health.Status) error {
+		attempts.Add(1)
+
+		return errors.New("unavailable")
+	}))
+
+	checker.Start(ctx)
+
+	handler := debug.Handler(checker)
+
+	var snapshot debug.Snapshot
+
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/health", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+
+		return snapshot.Reporters[0].LastError != ""
+	}, 8*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, "1s", snapshot.Options.Period)
+	assert.Equal(t, 2, snapshot.Options.FailureThreshold)
+	assert.GreaterOrEqual(t, snapshot.ConsecutiveFailures, 2)
+	assert.Zero(t, snapshot.ConsecutiveSuccesses)
+	assert.GreaterOrEqual(t, snapshot.Checks, uint64(2))
+	assert.Equal(t, 2, snapshot.Watchers, "the returned watcher and the reporting one")
+
+	assert.False(t, snapshot.Probes["db"].Healthy)
+	assert.Equal(t, "connection refused", snapshot.Probes["db"].LastError)
+	assert.True(t, snapshot.Probes["cache"].Healthy)
+	assert.Equal(t, "5s", snapshot.Probes["cache"].Timeout)
+
+	assert.Equal(t, "health.ReporterFunc", snapshot.Reporters[0].Type)
+	assert.Equal(t, "unavailable", snapshot.Reporters[0].LastError)
+	assert.Zero(t, snapshot.Reporters[0].Reports)
+}
+
+func TestHandler_RedactedErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	checker, err := health.NewChecker(health.WithPeriod(time.Second))
+	require.NoError(t, err)
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error {
+		return errors.New("dial tcp db.internal:5432: connection refused")
+	}))
+
+	checker.Start(ctx)
+
+	handler := debug.Handler(checker, debug.WithRedactedErrors())
+
+	var snapshot debug.Snapshot
+
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/health", nil))
+
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+
+		return snapshot.Probes["db"].LastCheck != nil
+	}, 8*time.Second, 50*time.Millisecond)
+
+	assert.False(t, snapshot.Probes["db"].Healthy)
+	assert.Equal(t, "[redacted]", snapshot.Probes["db"].LastError)
+}
+
+func TestPublish(t *testing.T) {
+	checker, err := health.NewChecker()
+	require.NoError(t, err)
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error { return nil }))
+
+	debug.Publish("health_test", checker)
+
+	var snapshot debug.Snapshot
+
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("health_test").String()), &snapshot))
+	assert.Contains(t, snapshot.Probes, "db")
+	assert.Nil(t, snapshot.Probes["db"].LastCheck)
+}
@@ -0,0 +1,28 @@
+package debug
+
+// Option is a functional option for Handler and Publish.
+type Option func(*options)
+
+type options struct {
+	redactErrors bool
+}
+
+// WithRedactedErrors replaces the errors of probes and reporters with a fixed
+// placeholder, as they may hold connection strings, host names or other
+// details of the dependencies. Whether a probe or reporter failed is still
+// served.
+func WithRedactedErrors() Option {
+	return func(o *options) {
+		o.redactErrors = true
+	}
+}
+
+func newOptions(o []Option) *options {
+	opts := &options{}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return opts
+}
@@ -0,0 +1,36 @@
+// Package httpretry classifies the responses of the HTTP endpoints reporters
+// deliver events to, so every reporter retries the same failures.
+package httpretry
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// maxDrainSize is the maximum number of bytes read from a response body
+// before closing it, so the connection can be reused.
+const maxDrainSize = 64 << 10
+
+// Check drains and closes the body of the given response, and returns nil if
+// its status is 2xx. 5xx, 408 and 429 responses are returned as errors to be
+// retried, while other responses are returned as permanent errors.
+func Check(res *http.Response) error {
+	defer func() { _ = res.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxDrainSize))
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	err := fmt.Errorf("unexpected response status %d", res.StatusCode)
+
+	if res.StatusCode >= 500 || res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+
+	return backoff.Permanent(err)
+}
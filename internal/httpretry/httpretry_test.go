@@ -0,0 +1,47 @@
+package httpretry_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/botchris/go-health/internal/httpretry"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		code      int
+		wantErr   bool
+		permanent bool
+	}{
+		{code: http.StatusOK},
+		{code: http.StatusAccepted},
+		{code: http.StatusInternalServerError, wantErr: true},
+		{code: http.StatusRequestTimeout, wantErr: true},
+		{code: http.StatusTooManyRequests, wantErr: true},
+		{code: http.StatusBadRequest, wantErr: true, permanent: true},
+		{code: http.StatusNotFound, wantErr: true, permanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			res := &http.Response{StatusCode: tt.code, Body: io.NopCloser(strings.NewReader("body"))}
+
+			err := httpretry.Check(res)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var permanent *backoff.PermanentError
+
+			assert.Error(t, err)
+			assert.Equal(t, tt.permanent, errors.As(err, &permanent))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Package summary describes a status in a single line, for the reporters
+// whose target only accepts a short free text, such as the systemd STATUS
+// field or the Consul check output.
+package summary
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/botchris/go-health"
+)
+
+// Line describes the given status in a single line, for example
+// "1/3 probes failing: db". If withErrors is set, the error of each failing
+// probe is appended to its name, for example "db (connection refused)".
+func Line(status health.Status, withErrors bool) string {
+	errs := status.Errors()
+	failing := make([]string, 0, len(errs))
+
+	for name, err := range errs {
+		if err != nil {
+			failing = append(failing, name)
+		}
+	}
+
+	if len(failing) == 0 {
+		return fmt.Sprintf("%d/%d probes passing", len(errs), len(errs))
+	}
+
+	sort.Strings(failing)
+
+	if withErrors {
+		for i, name := range failing {
+			failing[i] = fmt.Sprintf("%s (%v)", name, errs[name])
+		}
+	}
+
+	return fmt.Sprintf("%d/%d probes failing: %s", len(failing), len(errs), strings.Join(failing, ", "))
+}
@@ -0,0 +1,19 @@
+package summary_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/summary"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLine(t *testing.T) {
+	status := health.NewStatus().Append("db", nil).Append("cache", nil)
+	assert.Equal(t, "2/2 probes passing", summary.Line(status, true))
+
+	status = status.Append("queue", errors.New("timeout")).Append("api", errors.New("refused"))
+	assert.Equal(t, "2/4 probes failing: api, queue", summary.Line(status, false))
+	assert.Equal(t, "2/4 probes failing: api (refused), queue (timeout)", summary.Line(status, true))
+}
@@ -0,0 +1,82 @@
+// Package transition tells which probes transitioned between passing and
+// failing since the last status delivered by a reporter, so every reporter
+// reporting transitions shares the same definition of a transition.
+package transition
+
+import (
+	"sort"
+
+	"github.com/botchris/go-health"
+)
+
+// Change is a probe which transitioned between passing and failing.
+type Change struct {
+	// Probe is the name of the probe.
+	Probe string
+
+	// Err is the error returned by the probe, nil if it now passes.
+	Err error
+
+	// Known tells whether the probe was part of the last delivered status.
+	// Probes seen for the first time are only a change if failing.
+	Known bool
+}
+
+// Failing tells whether the probe now fails.
+func (c Change) Failing() bool {
+	return c.Err != nil
+}
+
+// State is the failing state of each probe of a status.
+type State map[string]bool
+
+// Tracker keeps the failing state of each probe as of the last delivered
+// status. A probe transitions when its failing state differs from the one it
+// had in the last delivered status, and a probe seen for the first time is
+// only considered a transition if failing. Probes no longer reported are
+// forgotten once a status without them is delivered.
+//
+// The zero value is ready to use. A Tracker is not safe for concurrent use.
+type Tracker struct {
+	failing State
+}
+
+// Diff returns the changes of the given status since the last delivered one,
+// sorted by probe name, and the state to be committed once the status has
+// been delivered.
+func (t *Tracker) Diff(status health.Status) ([]Change, State) {
+	errs := status.Errors()
+	state := make(State, len(errs))
+	changes := make([]Change, 0)
+
+	for name, err := range errs {
+		state[name] = err != nil
+
+		was, known := t.failing[name]
+		if (known && was != state[name]) || (!known && state[name]) {
+			changes = append(changes, Change{Probe: name, Err: err, Known: known})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Probe < changes[j].Probe })
+
+	return changes, state
+}
+
+// Commit records the given state, as returned by Diff, as delivered.
+func (t *Tracker) Commit(state State) {
+	t.failing = state
+}
+
+// CommitChanges records only the given changes, as returned by Diff, as
+// delivered. It is used when a status is partially delivered, so the
+// delivered changes are not reported again.
+func (t *Tracker) CommitChanges(changes []Change) {
+	if t.failing == nil {
+		t.failing = make(State, len(changes))
+	}
+
+	for _, c := range changes {
+		t.failing[c.Probe] = c.Failing()
+	}
+}
@@ -0,0 +1,57 @@
+package transition_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := transition.Tracker{}
+
+	// Probes seen for the first time are only a change if failing.
+	changes, state := tracker.Diff(health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout")))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "cache", changes[0].Probe)
+	assert.True(t, changes[0].Failing())
+	assert.False(t, changes[0].Known)
+
+	// Nothing is delivered until committed.
+	changes, _ = tracker.Diff(health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout")))
+	require.Len(t, changes, 1)
+
+	tracker.Commit(state)
+
+	changes, _ = tracker.Diff(health.NewStatus().Append("db", nil).Append("cache", errors.New("refused")))
+	assert.Empty(t, changes, "a different error is not a transition")
+
+	changes, state = tracker.Diff(health.NewStatus().Append("db", errors.New("refused")).Append("cache", nil))
+	require.Len(t, changes, 2)
+	assert.Equal(t, "cache", changes[0].Probe)
+	assert.False(t, changes[0].Failing())
+	assert.True(t, changes[0].Known)
+	assert.Equal(t, "db", changes[1].Probe)
+	assert.True(t, changes[1].Failing())
+
+	tracker.Commit(state)
+
+	changes, _ = tracker.Diff(health.NewStatus().Append("cache", nil))
+	assert.Empty(t, changes, "removed probes are not a transition")
+}
+
+func TestTracker_CommitChanges(t *testing.T) {
+	tracker := transition.Tracker{}
+
+	changes, _ := tracker.Diff(health.NewStatus().Append("db", errors.New("refused")).Append("cache", errors.New("timeout")))
+	require.Len(t, changes, 2)
+
+	tracker.CommitChanges(changes[:1])
+
+	changes, _ = tracker.Diff(health.NewStatus().Append("db", errors.New("refused")).Append("cache", errors.New("timeout")))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "db", changes[0].Probe, "only the undelivered change remains")
+}
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Override forces the result of a probe, or of every probe, for a bounded
+// time, for example to mark an instance unhealthy while draining it, or to
+// ignore the failures of a dependency during a planned maintenance.
+//
+// Overridden probes are still checked, but their result is replaced: by nil
+// if Healthy is set, or by an *OverrideError otherwise. The applied overrides
+// are available to reporters through the Overrides function.
+type Override struct {
+	// Probe is the name of the overridden probe. If empty, every probe is
+	// overridden, regardless of the overrides set for individual probes.
+	Probe string
+
+	// Healthy is the state the probe is forced into.
+	Healthy bool
+
+	// Reason describes why the override was set, for example "draining".
+	Reason string
+
+	// Until is the time the override expires.
+	Until time.Time
+}
+
+// OverrideError is the result of the probes forced into an unhealthy state.
+type OverrideError struct {
+	Override Override
+}
+
+func (e *OverrideError) Error() string {
+	return fmt.Sprintf("overridden until %s: %s", e.Override.Until.Format(time.RFC3339), e.Override.Reason)
+}
+
+// SetOverride forces the result of a probe, or of every probe, until the
+// given time. It replaces any override previously set for the same probe,
+// and returns an error if the probe is not registered. The override is applied from the next check, and the first Status with
+// the override applied is notified regardless of the success and failure
+// thresholds, so forcing a state takes effect immediately. The same applies
+// to the first Status after the override is cleared or expires.
+func (ch *Checker) SetOverride(o Override) error {
+	if o.Reason == "" {
+		return errors.New("override reason cannot be empty")
+	}
+
+	if !o.Until.After(time.Now()) {
+		return errors.New("override must expire in the future")
+	}
+
+	if o.Probe != "" && !ch.hasProbe(o.Probe) {
+		return fmt.Errorf("unknown probe %q", o.Probe)
+	}
+
+	ch.overridesMu.Lock()
+	defer ch.overridesMu.Unlock()
+
+	ch.overrides[o.Probe] = o
+
+	return nil
+}
+
+// ClearOverride removes the override set for the given probe, or the one
+// set for every probe if the name is empty. It returns false if there was
+// no active override.
+func (ch *Checker) ClearOverride(probe string) bool {
+	ch.overridesMu.Lock()
+	defer ch.overridesMu.Unlock()
+
+	o, ok := ch.overrides[probe]
+	delete(ch.overrides, probe)
+
+	return ok && o.Until.After(time.Now())
+}
+
+// Overrides returns the active overrides, sorted by probe name.
+func (ch *Checker) Overrides() []Override {
+	ch.overridesMu.Lock()
+	defer ch.overridesMu.Unlock()
+
+	now := time.Now()
+	out := make([]Override, 0, len(ch.overrides))
+
+	for probe, o := range ch.overrides {
+		if !o.Until.After(now) {
+			delete(ch.overrides, probe)
+
+			continue
+		}
+
+		out = append(out, o)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Probe < out[j].Probe })
+
+	return out
+}
+
+// hasProbe tells whether a probe with the given name is registered.
+func (ch *Checker) hasProbe(name string) bool {
+	ch.chMu.RLock()
+	defer ch.chMu.RUnlock()
+
+	_, ok := ch.probes[name]
+
+	return ok
+}
+
+// overrideFor returns the active override of the given probe, if any.
+func (ch *Checker) overrideFor(probe string, now time.Time) (Override, bool) {
+	ch.overridesMu.Lock()
+	defer ch.overridesMu.Unlock()
+
+	for _, name := range []string{"", probe} {
+		o, ok := ch.overrides[name]
+		if !ok {
+			continue
+		}
+
+		if o.Until.After(now) {
+			return o, true
+		}
+
+		delete(ch.overrides, name)
+	}
+
+	return Override{}, false
+}
+
+// appendResult appends the result of a probe to the given Status,
+// applying its active override, if any.
+func (ch *Checker) appendResult(st *status, probe string, result error) {
+	o, ok := ch.overrideFor(probe, time.Now())
+	if !ok {
+		st.Append(probe, result)
+
+		return
+	}
+
+	result = nil
+	if !o.Healthy {
+		result = &OverrideError{Override: o}
+	}
+
+	st.AppendOverridden(probe, result, o)
+}
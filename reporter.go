@@ -7,3 +7,11 @@ type Reporter interface {
 	// Report is used to notify the reporter of the current health status.
 	Report(ctx context.Context, status Status) error
 }
+
+// ReporterFunc is an adapter to allow the use of ordinary functions as Reporter.
+type ReporterFunc func(ctx context.Context, status Status) error
+
+// Report calls f(ctx, status).
+func (f ReporterFunc) Report(ctx context.Context, status Status) error {
+	return f(ctx, status)
+}
@@ -0,0 +1,83 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// AlertName is the "alertname" label of the alerts sent to Alertmanager.
+const AlertName = "HealthCheckFailed"
+
+type alertmanager struct {
+	*reporter
+}
+
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+// NewAlertmanager creates a new reporter which fires a Prometheus Alertmanager
+// alert using the "/api/v2/alerts" endpoint of the given Alertmanager URL
+// when a probe fails, and resolves it when the probe recovers.
+//
+// Alerts are labeled with "alertname", "service", "probe", "instance" and
+// "severity", which Alertmanager uses to deduplicate them. As Alertmanager
+// resolves alerts which are not refreshed, firing alerts are sent again on
+// every report.
+func NewAlertmanager(url, service string, o ...Option) (health.Reporter, error) {
+	endpoint := strings.TrimSuffix(url, "/") + "/api/v2/alerts"
+
+	r, err := newReporter(service, o, options{endpoint: endpoint})
+	if err != nil {
+		return nil, fmt.Errorf("alertmanager reporter: %w", err)
+	}
+
+	r.sender = &alertmanager{reporter: r}
+	r.resend = true
+
+	return r, nil
+}
+
+func (a *alertmanager) send(ctx context.Context, alerts []alert) (int, error) {
+	payload := make([]alertmanagerAlert, 0, len(alerts))
+
+	for i := range alerts {
+		out := alertmanagerAlert{
+			Labels: map[string]string{
+				"alertname": AlertName,
+				"service":   a.service,
+				"probe":     alerts[i].probe,
+				"instance":  a.opts.source,
+				"severity":  alerts[i].severity,
+			},
+			Annotations: map[string]string{
+				"summary": a.summary(alerts[i]),
+			},
+			StartsAt: alerts[i].startsAt,
+		}
+
+		if alerts[i].err != "" {
+			out.Annotations["description"] = alerts[i].err
+		}
+
+		if !alerts[i].firing {
+			endsAt := alerts[i].endsAt
+			out.EndsAt = &endsAt
+		}
+
+		payload = append(payload, out)
+	}
+
+	if err := a.post(ctx, payload); err != nil {
+		return 0, fmt.Errorf("alertmanager reporter: sending %d alerts failed: %w", len(payload), err)
+	}
+
+	return len(alerts), nil
+}
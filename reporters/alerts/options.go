@@ -0,0 +1,68 @@
+package alerts
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Option is a functional option for the alerting reporters.
+type Option func(*options) error
+
+type options struct {
+	endpoint    string
+	client      *http.Client
+	source      string
+	nonCritical map[string]struct{}
+}
+
+// WithEndpoint overrides the URL alerts are sent to, for example to use a
+// PagerDuty EU account, or a local stub in tests.
+func WithEndpoint(url string) Option {
+	return func(o *options) error {
+		if url == "" {
+			return errors.New("endpoint cannot be empty")
+		}
+
+		o.endpoint = url
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the alerts.
+// Defaults to a client with a 10 seconds timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) error {
+		if client == nil {
+			return errors.New("http client cannot be nil")
+		}
+
+		o.client = client
+
+		return nil
+	}
+}
+
+// WithSource sets the instance raising the alerts, reported as the
+// PagerDuty "source" field or the Alertmanager "instance" label.
+// Defaults to the hostname.
+func WithSource(source string) Option {
+	return func(o *options) error {
+		o.source = source
+
+		return nil
+	}
+}
+
+// WithNonCriticalProbes marks the given probes as non-critical. Alerts of
+// non-critical probes are raised with "warning" severity instead of
+// "critical".
+func WithNonCriticalProbes(probes ...string) Option {
+	return func(o *options) error {
+		for i := range probes {
+			o.nonCritical[probes[i]] = struct{}{}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/botchris/go-health"
+)
+
+// PagerDutyEndpoint is the default URL of the PagerDuty Events API v2.
+const PagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
+
+type pagerDuty struct {
+	*reporter
+	routingKey string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	Component     string            `json:"component"`
+	Group         string            `json:"group"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+// NewPagerDuty creates a new reporter which triggers a PagerDuty incident
+// using the Events API v2 when a probe fails, and resolves it when the probe
+// recovers. Events are deduplicated using the "<service>/<probe>" key.
+func NewPagerDuty(routingKey, service string, o ...Option) (health.Reporter, error) {
+	r, err := newReporter(service, o, options{endpoint: PagerDutyEndpoint})
+	if err != nil {
+		return nil, fmt.Errorf("pagerduty reporter: %w", err)
+	}
+
+	r.sender = &pagerDuty{reporter: r, routingKey: routingKey}
+
+	return r, nil
+}
+
+func (p *pagerDuty) send(ctx context.Context, alerts []alert) (int, error) {
+	for i, a := range alerts {
+		event := pagerDutyEvent{
+			RoutingKey:  p.routingKey,
+			EventAction: "resolve",
+			DedupKey:    p.dedupKey(a.probe),
+		}
+
+		if a.firing {
+			event.EventAction = "trigger"
+			event.Payload = &pagerDutyPayload{
+				Summary:       p.summary(a),
+				Source:        p.opts.source,
+				Severity:      a.severity,
+				Timestamp:     a.startsAt.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+				Component:     a.probe,
+				Group:         p.service,
+				CustomDetails: map[string]string{"error": a.err},
+			}
+		}
+
+		if err := p.post(ctx, event); err != nil {
+			return i, fmt.Errorf("pagerduty reporter: %s event for %q failed: %w", event.EventAction, a.probe, err)
+		}
+	}
+
+	return len(alerts), nil
+}
@@ -0,0 +1,199 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/httpretry"
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	severityCritical = "critical"
+	severityWarning  = "warning"
+)
+
+// alert is the state of the alert of a single probe.
+type alert struct {
+	probe    string
+	firing   bool
+	severity string
+	err      string
+	startsAt time.Time
+	endsAt   time.Time
+}
+
+// sender delivers alerts to an alerting system.
+type sender interface {
+	// send delivers the given alerts in order, and returns how many of
+	// them were delivered, which is less than len(alerts) only on error.
+	send(ctx context.Context, alerts []alert) (int, error)
+}
+
+type reporter struct {
+	service string
+	opts    *options
+	sender  sender
+
+	// resend tells whether firing alerts must be sent on every report,
+	// and not only when they are raised.
+	resend bool
+
+	// firing holds the alerts raised and not yet resolved, by probe.
+	firing map[string]alert
+	mu     sync.Mutex
+}
+
+func newReporter(service string, o []Option, defaults options) (*reporter, error) {
+	opts := &defaults
+	opts.client = &http.Client{Timeout: 10 * time.Second}
+	opts.nonCritical = make(map[string]struct{})
+
+	if hostname, err := os.Hostname(); err == nil {
+		opts.source = hostname
+	}
+
+	for i := range o {
+		if err := o[i](opts); err != nil {
+			return nil, fmt.Errorf("applying option %d failed: %w", i, err)
+		}
+	}
+
+	return &reporter{
+		service: service,
+		opts:    opts,
+		firing:  make(map[string]alert),
+	}, nil
+}
+
+// Report raises an alert for every probe transitioning to failed, and
+// resolves the alerts of the probes which recovered.
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	errs := status.Errors()
+	firing := make(map[string]alert, len(r.firing))
+	out := make([]alert, 0)
+
+	for probe, err := range errs {
+		prev, wasFiring := r.firing[probe]
+
+		if err == nil {
+			continue
+		}
+
+		a := alert{
+			probe:    probe,
+			firing:   true,
+			severity: r.severity(probe),
+			err:      err.Error(),
+			startsAt: now,
+		}
+
+		if wasFiring {
+			a.startsAt = prev.startsAt
+		}
+
+		firing[probe] = a
+
+		if !wasFiring || r.resend {
+			out = append(out, a)
+		}
+	}
+
+	// Probes which recovered, or are no longer reported, are resolved.
+	for probe, a := range r.firing {
+		if _, ok := firing[probe]; ok {
+			continue
+		}
+
+		a.firing = false
+		a.endsAt = now
+		out = append(out, a)
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	out = sortAlerts(out)
+
+	delivered, err := r.sender.send(ctx, out)
+	if err == nil {
+		r.firing = firing
+
+		return nil
+	}
+
+	// Alerts delivered before the failure are recorded,
+	// so they are not sent again when the report is retried.
+	for _, a := range out[:delivered] {
+		if a.firing {
+			r.firing[a.probe] = a
+		} else {
+			delete(r.firing, a.probe)
+		}
+	}
+
+	return err
+}
+
+func (r *reporter) severity(probe string) string {
+	if _, ok := r.opts.nonCritical[probe]; ok {
+		return severityWarning
+	}
+
+	return severityCritical
+}
+
+// dedupKey returns the stable key identifying the alerts of a probe.
+func (r *reporter) dedupKey(probe string) string {
+	return r.service + "/" + probe
+}
+
+func (r *reporter) summary(a alert) string {
+	if a.firing {
+		return fmt.Sprintf("%s: health check %q failed: %s", r.service, a.probe, a.err)
+	}
+
+	return fmt.Sprintf("%s: health check %q recovered", r.service, a.probe)
+}
+
+// post sends the given payload as JSON to the configured endpoint. Failed
+// requests are classified for retrying by httpretry.Check.
+func (r *reporter) post(ctx context.Context, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("encoding payload failed: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.opts.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("creating request failed: %w", err))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.opts.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request failed: %w", err)
+	}
+
+	return httpretry.Check(res)
+}
+
+func sortAlerts(alerts []alert) []alert {
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].probe < alerts[j].probe })
+
+	return alerts
+}
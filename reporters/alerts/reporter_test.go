@@ -0,0 +1,218 @@
+package alerts_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/alerts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerDuty_TriggersAndResolves(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stub := &stub{}
+	srv := httptest.NewServer(stub)
+
+	defer srv.Close()
+
+	reporter, err := alerts.NewPagerDuty("routing-key", "orders",
+		alerts.WithEndpoint(srv.URL),
+		alerts.WithSource("orders-7d9f"),
+		alerts.WithNonCriticalProbes("cache"),
+	)
+	require.NoError(t, err)
+
+	healthy := health.NewStatus().Append("db", nil).Append("cache", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", errors.New("timeout"))
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+	assert.Empty(t, stub.all())
+
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+
+	events := stub.all()
+	require.Len(t, events, 2, "alerts must be triggered once")
+
+	type event struct {
+		RoutingKey  string `json:"routing_key"`
+		EventAction string `json:"event_action"`
+		DedupKey    string `json:"dedup_key"`
+		Payload     struct {
+			Summary  string `json:"summary"`
+			Source   string `json:"source"`
+			Severity string `json:"severity"`
+		} `json:"payload"`
+	}
+
+	decode := func(body []byte) event {
+		e := event{}
+		require.NoError(t, json.Unmarshal(body, &e))
+
+		return e
+	}
+
+	cache, db := decode(events[0]), decode(events[1])
+	assert.Equal(t, "trigger", cache.EventAction)
+	assert.Equal(t, "orders/cache", cache.DedupKey)
+	assert.Equal(t, "warning", cache.Payload.Severity)
+	assert.Equal(t, "routing-key", db.RoutingKey)
+	assert.Equal(t, "orders/db", db.DedupKey)
+	assert.Equal(t, "critical", db.Payload.Severity)
+	assert.Equal(t, "orders-7d9f", db.Payload.Source)
+	assert.Contains(t, db.Payload.Summary, "connection refused")
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout"))))
+
+	events = stub.all()
+	require.Len(t, events, 3)
+
+	resolved := decode(events[2])
+	assert.Equal(t, "resolve", resolved.EventAction)
+	assert.Equal(t, "orders/db", resolved.DedupKey)
+}
+
+func TestAlertmanager_FiresAndResolves(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stub := &stub{}
+	srv := httptest.NewServer(stub)
+
+	defer srv.Close()
+
+	reporter, err := alerts.NewAlertmanager(srv.URL, "orders", alerts.WithSource("orders-7d9f"))
+	require.NoError(t, err)
+
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", nil)))
+
+	requests := stub.all()
+	require.Len(t, requests, 3, "firing alerts must be refreshed")
+	assert.Equal(t, "/api/v2/alerts", stub.path)
+
+	type alert struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		StartsAt    time.Time         `json:"startsAt"`
+		EndsAt      *time.Time        `json:"endsAt"`
+	}
+
+	decode := func(body []byte) []alert {
+		var out []alert
+		require.NoError(t, json.Unmarshal(body, &out))
+
+		return out
+	}
+
+	fired, refreshed, resolved := decode(requests[0]), decode(requests[1]), decode(requests[2])
+	require.Len(t, fired, 1)
+	assert.Equal(t, map[string]string{
+		"alertname": alerts.AlertName,
+		"service":   "orders",
+		"probe":     "db",
+		"instance":  "orders-7d9f",
+		"severity":  "critical",
+	}, fired[0].Labels)
+	assert.Equal(t, "connection refused", fired[0].Annotations["description"])
+	assert.Nil(t, fired[0].EndsAt)
+
+	require.Len(t, refreshed, 1)
+	assert.True(t, fired[0].StartsAt.Equal(refreshed[0].StartsAt))
+
+	require.Len(t, resolved, 1)
+	assert.Equal(t, fired[0].Labels, resolved[0].Labels)
+	require.NotNil(t, resolved[0].EndsAt)
+}
+
+func TestReporter_RetriesUndeliveredAlerts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stub := &stub{codes: []int{http.StatusServiceUnavailable}}
+	srv := httptest.NewServer(stub)
+
+	defer srv.Close()
+
+	reporter, err := alerts.NewPagerDuty("routing-key", "orders", alerts.WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	require.Error(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	assert.Len(t, stub.all(), 2)
+}
+
+func TestPagerDuty_SkipsDeliveredAlertsOnRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The "cache" event is delivered, while the "db" one fails.
+	stub := &stub{codes: []int{http.StatusAccepted, http.StatusServiceUnavailable}}
+	srv := httptest.NewServer(stub)
+
+	defer srv.Close()
+
+	reporter, err := alerts.NewPagerDuty("routing-key", "orders", alerts.WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", errors.New("timeout"))
+
+	require.Error(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+
+	events := stub.all()
+	require.Len(t, events, 3)
+	assert.Contains(t, string(events[0]), "orders/cache")
+	assert.Contains(t, string(events[1]), "orders/db")
+	assert.Contains(t, string(events[2]), "orders/db", "the delivered event must not be sent again")
+}
+
+// stub records the bodies of the received requests, answering them with
+// the given status codes in order, and 202 Accepted afterwards.
+type stub struct {
+	codes  []int
+	path   string
+	bodies [][]byte
+	mu     sync.Mutex
+}
+
+func (s *stub) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.path = req.URL.Path
+	s.bodies = append(s.bodies, body)
+
+	code := http.StatusAccepted
+	if len(s.codes) > 0 {
+		code, s.codes = s.codes[0], s.codes[1:]
+	}
+
+	w.WriteHeader(code)
+}
+
+func (s *stub) all() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]byte(nil), s.bodies...)
+}
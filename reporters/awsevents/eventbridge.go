@@ -0,0 +1,86 @@
+package awsevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/botchris/go-health"
+)
+
+// DetailType is the default detail type of EventBridge events.
+const DetailType = "Health Status Transition"
+
+// maxEntriesPerRequest is the maximum number of entries
+// accepted by a single PutEvents request.
+const maxEntriesPerRequest = 10
+
+var _ EventBridgeClient = (*eventbridge.Client)(nil)
+
+type eventBridgePublisher struct {
+	client  EventBridgeClient
+	busName string
+	opts    *options
+}
+
+// NewEventBridge creates a new reporter which puts an Event to the given
+// EventBridge bus every time a probe transitions between passing and failing.
+// Events are sent with the source and detail type set using WithSource and
+// WithDetailType, and the Event as detail, so rules can match them with
+// patterns such as:
+//
+//	{"source": ["go-health"], "detail": {"newState": ["unhealthy"]}}
+func NewEventBridge(client EventBridgeClient, busName string, o ...Option) health.Reporter {
+	opts := newOptions(o)
+
+	return &reporter{
+		opts:      opts,
+		publisher: &eventBridgePublisher{client: client, busName: busName, opts: opts},
+	}
+}
+
+func (p *eventBridgePublisher) publish(ctx context.Context, events []Event) error {
+	entries := make([]types.PutEventsRequestEntry, 0, len(events))
+
+	for _, e := range events {
+		detail, err := encode(e)
+		if err != nil {
+			return fmt.Errorf("eventbridge publisher: %w", err)
+		}
+
+		entries = append(entries, types.PutEventsRequestEntry{
+			EventBusName: aws.String(p.busName),
+			Source:       aws.String(p.opts.source),
+			DetailType:   aws.String(p.opts.detailType),
+			Detail:       aws.String(detail),
+		})
+	}
+
+	for start := 0; start < len(entries); start += maxEntriesPerRequest {
+		end := min(start+maxEntriesPerRequest, len(entries))
+
+		out, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{Entries: entries[start:end]})
+		if err != nil {
+			return fmt.Errorf("eventbridge publisher: putting events failed: %w", err)
+		}
+
+		if out.FailedEntryCount > 0 {
+			return fmt.Errorf("eventbridge publisher: %d of %d events failed: %s", out.FailedEntryCount, end-start, failure(out.Entries))
+		}
+	}
+
+	return nil
+}
+
+// failure describes the first failed entry of a PutEvents response.
+func failure(entries []types.PutEventsResultEntry) string {
+	for _, e := range entries {
+		if e.ErrorCode != nil {
+			return aws.ToString(e.ErrorCode) + ": " + aws.ToString(e.ErrorMessage)
+		}
+	}
+
+	return "unknown error"
+}
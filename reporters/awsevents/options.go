@@ -0,0 +1,42 @@
+package awsevents
+
+// Option is a functional option for the event publishers.
+type Option func(*options)
+
+type options struct {
+	service    string
+	instance   string
+	source     string
+	detailType string
+}
+
+// WithService sets the name of the service included in every event.
+func WithService(service string) Option {
+	return func(o *options) {
+		o.service = service
+	}
+}
+
+// WithInstance sets the instance included in every event.
+// Defaults to the hostname.
+func WithInstance(instance string) Option {
+	return func(o *options) {
+		o.instance = instance
+	}
+}
+
+// WithSource sets the source of EventBridge events.
+// Defaults to "go-health". Ignored by other publishers.
+func WithSource(source string) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// WithDetailType sets the detail type of EventBridge events.
+// Defaults to DetailType. Ignored by other publishers.
+func WithDetailType(detailType string) Option {
+	return func(o *options) {
+		o.detailType = detailType
+	}
+}
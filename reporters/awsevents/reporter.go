@@ -0,0 +1,127 @@
+package awsevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// States of a probe within an Event.
+const (
+	StateUnknown   = "unknown"
+	StateHealthy   = "healthy"
+	StateUnhealthy = "unhealthy"
+)
+
+// Event is the JSON document published every time a probe
+// transitions between passing and failing.
+type Event struct {
+	Service       string    `json:"service,omitempty"`
+	Instance      string    `json:"instance"`
+	Probe         string    `json:"probe"`
+	PreviousState string    `json:"previousState"`
+	NewState      string    `json:"newState"`
+	Error         string    `json:"error,omitempty"`
+	Time          time.Time `json:"time"`
+}
+
+// publisher delivers events to an AWS service.
+type publisher interface {
+	// publish delivers the given events, in order.
+	publish(ctx context.Context, events []Event) error
+}
+
+type reporter struct {
+	opts      *options
+	publisher publisher
+
+	tracker transition.Tracker
+	mu      sync.Mutex
+}
+
+func newOptions(o []Option) *options {
+	opts := &options{
+		source:     "go-health",
+		detailType: DetailType,
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		opts.instance = hostname
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return opts
+}
+
+// Report publishes an Event for every probe which transitioned between
+// passing and failing since the last report. Probes seen for the first time
+// are only published if failing, with StateUnknown as their previous state.
+//
+// Events are delivered at least once: if publishing fails, every event of
+// the report is published again when the Checker retries the report.
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes, next := r.tracker.Diff(status)
+	now := time.Now()
+	events := make([]Event, 0, len(changes))
+
+	for _, c := range changes {
+		e := Event{
+			Service:       r.opts.service,
+			Instance:      r.opts.instance,
+			Probe:         c.Probe,
+			PreviousState: state(!c.Failing()),
+			NewState:      state(c.Failing()),
+			Time:          now,
+		}
+
+		if !c.Known {
+			e.PreviousState = StateUnknown
+		}
+
+		if c.Failing() {
+			e.Error = c.Err.Error()
+		}
+
+		events = append(events, e)
+	}
+
+	if len(events) > 0 {
+		if err := r.publisher.publish(ctx, events); err != nil {
+			return err
+		}
+	}
+
+	r.tracker.Commit(next)
+
+	return nil
+}
+
+func state(failing bool) string {
+	if failing {
+		return StateUnhealthy
+	}
+
+	return StateHealthy
+}
+
+func encode(e Event) (string, error) {
+	out, err := json.Marshal(e)
+	if err != nil {
+		return "", backoff.Permanent(fmt.Errorf("encoding event failed: %w", err))
+	}
+
+	return string(out), nil
+}
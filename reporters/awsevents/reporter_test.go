@@ -0,0 +1,189 @@
+package awsevents_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	ebtypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/awsevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSNS_PublishesTransitions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var inputs []*sns.PublishInput
+
+	client := &mockSNSClient{
+		publishFunc: func(_ context.Context, params *sns.PublishInput, _ ...func(*sns.Options)) (*sns.PublishOutput, error) {
+			inputs = append(inputs, params)
+
+			return &sns.PublishOutput{}, nil
+		},
+	}
+
+	reporter := awsevents.NewSNS(client, "arn:aws:sns:us-east-1:123456789012:health",
+		awsevents.WithService("orders"),
+		awsevents.WithInstance("orders-1"),
+	)
+
+	healthy := health.NewStatus().Append("db", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	// Passing probes seen for the first time are not a transition.
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.Len(t, inputs, 2)
+
+	assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:health", aws.ToString(inputs[0].TopicArn))
+	assert.Equal(t, "db", aws.ToString(inputs[0].MessageAttributes["probe"].StringValue))
+	assert.Equal(t, "unhealthy", aws.ToString(inputs[0].MessageAttributes["state"].StringValue))
+
+	var first, second awsevents.Event
+
+	require.NoError(t, json.Unmarshal([]byte(aws.ToString(inputs[0].Message)), &first))
+	require.NoError(t, json.Unmarshal([]byte(aws.ToString(inputs[1].Message)), &second))
+
+	assert.Equal(t, "orders", first.Service)
+	assert.Equal(t, "orders-1", first.Instance)
+	assert.Equal(t, "db", first.Probe)
+	assert.Equal(t, awsevents.StateHealthy, first.PreviousState)
+	assert.Equal(t, awsevents.StateUnhealthy, first.NewState)
+	assert.Equal(t, "connection refused", first.Error)
+
+	assert.Equal(t, awsevents.StateUnhealthy, second.PreviousState)
+	assert.Equal(t, awsevents.StateHealthy, second.NewState)
+	assert.Empty(t, second.Error)
+}
+
+func TestSQS_RetriesUntilDelivered(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var bodies []string
+
+	fail := true
+	client := &mockSQSClient{
+		sendMessageFunc: func(_ context.Context, params *sqs.SendMessageInput, _ ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+			if fail {
+				return nil, errors.New("throttled")
+			}
+
+			bodies = append(bodies, aws.ToString(params.MessageBody))
+
+			return &sqs.SendMessageOutput{}, nil
+		},
+	}
+
+	reporter := awsevents.NewSQS(client, "https://sqs.us-east-1.amazonaws.com/123456789012/health")
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	require.Error(t, reporter.Report(ctx, unhealthy))
+
+	fail = false
+
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.Len(t, bodies, 1)
+
+	var e awsevents.Event
+
+	require.NoError(t, json.Unmarshal([]byte(bodies[0]), &e))
+	assert.Equal(t, awsevents.StateUnknown, e.PreviousState)
+	assert.Equal(t, awsevents.StateUnhealthy, e.NewState)
+}
+
+func TestEventBridge_PutsEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := &mockEventBridgeClient{}
+	reporter := awsevents.NewEventBridge(client, "health-bus", awsevents.WithSource("orders"))
+
+	status := health.NewStatus()
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		status = status.Append(name, errors.New("timeout"))
+	}
+
+	require.NoError(t, reporter.Report(ctx, status))
+	require.Len(t, client.calls, 2)
+	assert.Len(t, client.calls[0].Entries, 10)
+	assert.Len(t, client.calls[1].Entries, 2)
+
+	entry := client.calls[0].Entries[0]
+	assert.Equal(t, "health-bus", aws.ToString(entry.EventBusName))
+	assert.Equal(t, "orders", aws.ToString(entry.Source))
+	assert.Equal(t, awsevents.DetailType, aws.ToString(entry.DetailType))
+	assert.Contains(t, aws.ToString(entry.Detail), `"probe":"a"`)
+}
+
+func TestEventBridge_FailedEntries(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := &mockEventBridgeClient{
+		out: &eventbridge.PutEventsOutput{
+			FailedEntryCount: 1,
+			Entries: []ebtypes.PutEventsResultEntry{
+				{ErrorCode: aws.String("ThrottlingException"), ErrorMessage: aws.String("rate exceeded")},
+			},
+		},
+	}
+	reporter := awsevents.NewEventBridge(client, "health-bus")
+	status := health.NewStatus().Append("db", errors.New("timeout"))
+
+	err := reporter.Report(ctx, status)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ThrottlingException: rate exceeded")
+
+	client.out = nil
+
+	require.NoError(t, reporter.Report(ctx, status))
+	assert.Len(t, client.calls, 2, "failed events must be sent again")
+}
+
+type mockSNSClient struct {
+	publishFunc func(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+func (m *mockSNSClient) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	return m.publishFunc(ctx, params, optFns...)
+}
+
+type mockSQSClient struct {
+	sendMessageFunc func(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+func (m *mockSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	return m.sendMessageFunc(ctx, params, optFns...)
+}
+
+type mockEventBridgeClient struct {
+	calls []*eventbridge.PutEventsInput
+	out   *eventbridge.PutEventsOutput
+}
+
+func (m *mockEventBridgeClient) PutEvents(
+	_ context.Context,
+	params *eventbridge.PutEventsInput,
+	_ ...func(*eventbridge.Options),
+) (*eventbridge.PutEventsOutput, error) {
+	m.calls = append(m.calls, params)
+
+	if m.out != nil {
+		return m.out, nil
+	}
+
+	return &eventbridge.PutEventsOutput{}, nil
+}
@@ -0,0 +1,50 @@
+package awsevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/botchris/go-health"
+)
+
+type snsPublisher struct {
+	client   SNSClient
+	topicARN string
+}
+
+// NewSNS creates a new reporter which publishes an Event to the given SNS
+// topic every time a probe transitions between passing and failing. The
+// probe and its new state are also set as the "probe" and "state" message
+// attributes, so subscriptions can filter events using filter policies.
+func NewSNS(client SNSClient, topicARN string, o ...Option) health.Reporter {
+	return &reporter{
+		opts:      newOptions(o),
+		publisher: &snsPublisher{client: client, topicARN: topicARN},
+	}
+}
+
+func (p *snsPublisher) publish(ctx context.Context, events []Event) error {
+	for _, e := range events {
+		body, err := encode(e)
+		if err != nil {
+			return fmt.Errorf("sns publisher: %w", err)
+		}
+
+		_, err = p.client.Publish(ctx, &sns.PublishInput{
+			TopicArn: aws.String(p.topicARN),
+			Message:  aws.String(body),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"probe": {DataType: aws.String("String"), StringValue: aws.String(e.Probe)},
+				"state": {DataType: aws.String("String"), StringValue: aws.String(e.NewState)},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("sns publisher: publishing event failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package awsevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/botchris/go-health"
+)
+
+type sqsPublisher struct {
+	client   SQSClient
+	queueURL string
+}
+
+// NewSQS creates a new reporter which sends an Event to the given SQS queue
+// every time a probe transitions between passing and failing. The probe and
+// its new state are also set as the "probe" and "state" message attributes.
+func NewSQS(client SQSClient, queueURL string, o ...Option) health.Reporter {
+	return &reporter{
+		opts:      newOptions(o),
+		publisher: &sqsPublisher{client: client, queueURL: queueURL},
+	}
+}
+
+func (p *sqsPublisher) publish(ctx context.Context, events []Event) error {
+	for _, e := range events {
+		body, err := encode(e)
+		if err != nil {
+			return fmt.Errorf("sqs publisher: %w", err)
+		}
+
+		_, err = p.client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:    aws.String(p.queueURL),
+			MessageBody: aws.String(body),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"probe": {DataType: aws.String("String"), StringValue: aws.String(e.Probe)},
+				"state": {DataType: aws.String("String"), StringValue: aws.String(e.NewState)},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("sqs publisher: sending message failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/botchris/go-health"
+)
+
+type emfReporter struct {
+	w         io.Writer
+	namespace string
+	opts      *options
+	mu        sync.Mutex
+}
+
+// emfMetadata is the "_aws" member of an Embedded Metric Format document.
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// NewEMF creates a new reporter which writes the metrics described in New to
+// the given writer, typically os.Stdout, as CloudWatch Embedded Metric Format
+// documents, one JSON document per line. In AWS Lambda, or in ECS with the
+// awslogs driver and the CloudWatch agent, these lines are turned into metrics
+// by CloudWatch Logs without calling the CloudWatch API.
+func NewEMF(w io.Writer, namespace string, o ...Option) health.Reporter {
+	opts := &options{}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return &emfReporter{w: w, namespace: namespace, opts: opts}
+}
+
+func (r *emfReporter) Report(_ context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	for _, rec := range records(status, r.opts, now) {
+		line, err := r.encode(rec, now)
+		if err != nil {
+			return fmt.Errorf("cloudwatch reporter: encoding metrics failed: %w", err)
+		}
+
+		if _, err := r.w.Write(line); err != nil {
+			return fmt.Errorf("cloudwatch reporter: writing metrics failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *emfReporter) encode(rec record, now time.Time) ([]byte, error) {
+	dims := make([]string, 0, len(rec.dimensions))
+	doc := make(map[string]any, len(rec.dimensions)+len(rec.metrics)+1)
+
+	for _, d := range rec.dimensions {
+		dims = append(dims, aws.ToString(d.Name))
+		doc[aws.ToString(d.Name)] = aws.ToString(d.Value)
+	}
+
+	directive := emfDirective{
+		Namespace:  r.namespace,
+		Dimensions: [][]string{dims},
+	}
+
+	for _, m := range rec.metrics {
+		name := aws.ToString(m.MetricName)
+		directive.Metrics = append(directive.Metrics, emfMetric{Name: name, Unit: string(m.Unit)})
+		doc[name] = aws.ToFloat64(m.Value)
+	}
+
+	doc["_aws"] = emfMetadata{
+		Timestamp:         now.UnixMilli(),
+		CloudWatchMetrics: []emfDirective{directive},
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,88 @@
+package cloudwatch
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/botchris/go-health"
+)
+
+// Names of the published metrics and dimensions.
+const (
+	// MetricHealthy is 1 if every probe passes, 0 otherwise.
+	MetricHealthy = "Healthy"
+
+	// MetricProbeHealthy is 1 if the probe passes, 0 otherwise.
+	MetricProbeHealthy = "ProbeHealthy"
+
+	// MetricProbeLatency is the time the probe took to complete, in milliseconds.
+	MetricProbeLatency = "ProbeLatency"
+
+	// DimensionProbe is the dimension holding the name of the probe
+	// of the MetricProbeHealthy and MetricProbeLatency metrics.
+	DimensionProbe = "Probe"
+)
+
+// record is a set of metrics sharing the same dimensions.
+type record struct {
+	dimensions []types.Dimension
+	metrics    []types.MetricDatum
+}
+
+// records returns the metrics for the given status: one record for the
+// overall health, and one record per probe sorted by name.
+func records(status health.Status, opts *options, now time.Time) []record {
+	errs := status.Errors()
+	durations := health.Durations(status)
+
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	datum := func(name string, dims []types.Dimension, unit types.StandardUnit, value float64) types.MetricDatum {
+		return types.MetricDatum{
+			MetricName: aws.String(name),
+			Dimensions: dims,
+			Timestamp:  aws.Time(now),
+			Unit:       unit,
+			Value:      aws.Float64(value),
+		}
+	}
+
+	out := make([]record, 0, len(names)+1)
+	out = append(out, record{
+		dimensions: opts.dimensions,
+		metrics:    []types.MetricDatum{datum(MetricHealthy, opts.dimensions, types.StandardUnitNone, boolValue(status.AsError() == nil))},
+	})
+
+	for _, name := range names {
+		dims := make([]types.Dimension, 0, len(opts.dimensions)+1)
+		dims = append(dims, opts.dimensions...)
+		dims = append(dims, types.Dimension{Name: aws.String(DimensionProbe), Value: aws.String(name)})
+
+		latency := float64(durations[name].Microseconds()) / 1000
+
+		out = append(out, record{
+			dimensions: dims,
+			metrics: []types.MetricDatum{
+				datum(MetricProbeHealthy, dims, types.StandardUnitNone, boolValue(errs[name] == nil)),
+				datum(MetricProbeLatency, dims, types.StandardUnitMilliseconds, latency),
+			},
+		})
+	}
+
+	return out
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
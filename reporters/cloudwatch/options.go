@@ -0,0 +1,21 @@
+package cloudwatch
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// Option is a functional option for the CloudWatch reporters.
+type Option func(*options)
+
+type options struct {
+	dimensions []types.Dimension
+}
+
+// WithDimension adds a dimension to every published metric, for example
+// the name of the service or the ID of the instance.
+func WithDimension(name, value string) Option {
+	return func(o *options) {
+		o.dimensions = append(o.dimensions, types.Dimension{Name: aws.String(name), Value: aws.String(value)})
+	}
+}
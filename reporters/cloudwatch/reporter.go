@@ -0,0 +1,71 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/botchris/go-health"
+)
+
+// maxDatumsPerRequest is the maximum number of metrics
+// accepted by a single PutMetricData request.
+const maxDatumsPerRequest = 1000
+
+// Client captures the methods we need from a CloudWatch client.
+type Client interface {
+	PutMetricData(ctx context.Context, params *cloudwatch.PutMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricDataOutput, error)
+}
+
+var _ Client = (*cloudwatch.Client)(nil)
+
+type putReporter struct {
+	client    Client
+	namespace string
+	opts      *options
+}
+
+// New creates a new reporter which publishes the health and latency of every
+// probe to the given CloudWatch namespace using PutMetricData:
+//
+//   - MetricHealthy, with the dimensions set using WithDimension.
+//   - MetricProbeHealthy and MetricProbeLatency, with an additional
+//     DimensionProbe dimension holding the name of the probe.
+//
+// Use NewEMF instead to publish the metrics without calling the CloudWatch
+// API, for example in AWS Lambda functions.
+func New(client Client, namespace string, o ...Option) health.Reporter {
+	opts := &options{}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return &putReporter{client: client, namespace: namespace, opts: opts}
+}
+
+func (r *putReporter) Report(ctx context.Context, status health.Status) error {
+	data := make([]types.MetricDatum, 0)
+
+	for _, rec := range records(status, r.opts, time.Now()) {
+		data = append(data, rec.metrics...)
+	}
+
+	for start := 0; start < len(data); start += maxDatumsPerRequest {
+		end := min(start+maxDatumsPerRequest, len(data))
+
+		input := &cloudwatch.PutMetricDataInput{
+			Namespace:  aws.String(r.namespace),
+			MetricData: data[start:end],
+		}
+
+		if _, err := r.client.PutMetricData(ctx, input); err != nil {
+			return fmt.Errorf("cloudwatch reporter: putting metric data failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package cloudwatch_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awscloudwatch "github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/cloudwatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_PutMetricData(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client := &fakeClient{}
+	reporter := cloudwatch.New(client, "MyApp/Health", cloudwatch.WithDimension("Service", "orders"))
+	status := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(ctx, status))
+	require.Len(t, client.calls, 1)
+	assert.Equal(t, "MyApp/Health", aws.ToString(client.calls[0].Namespace))
+
+	got := make(map[string]float64)
+
+	for _, d := range client.calls[0].MetricData {
+		key := aws.ToString(d.MetricName)
+		for _, dim := range d.Dimensions {
+			key += " " + aws.ToString(dim.Name) + "=" + aws.ToString(dim.Value)
+		}
+
+		got[key] = aws.ToFloat64(d.Value)
+
+		if aws.ToString(d.MetricName) == cloudwatch.MetricProbeLatency {
+			assert.Equal(t, types.StandardUnitMilliseconds, d.Unit)
+		}
+	}
+
+	assert.Len(t, got, 5)
+	assert.InDelta(t, 0, got["Healthy Service=orders"], 0)
+	assert.InDelta(t, 0, got["ProbeHealthy Service=orders Probe=db"], 0)
+	assert.InDelta(t, 1, got["ProbeHealthy Service=orders Probe=cache"], 0)
+	assert.Contains(t, got, "ProbeLatency Service=orders Probe=db")
+	assert.Contains(t, got, "ProbeLatency Service=orders Probe=cache")
+}
+
+func TestReporter_PutMetricDataError(t *testing.T) {
+	client := &fakeClient{err: errors.New("throttled")}
+	reporter := cloudwatch.New(client, "MyApp/Health")
+
+	assert.Error(t, reporter.Report(context.Background(), health.NewStatus().Append("db", nil)))
+}
+
+func TestReporter_EMF(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporter := cloudwatch.NewEMF(&buf, "MyApp/Health", cloudwatch.WithDimension("Service", "orders"))
+	status := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	require.NoError(t, reporter.Report(context.Background(), status))
+
+	var docs []map[string]any
+
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		doc := make(map[string]any)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &doc))
+
+		docs = append(docs, doc)
+	}
+
+	require.Len(t, docs, 2)
+
+	assert.Equal(t, "orders", docs[0]["Service"])
+	assert.InDelta(t, 0, docs[0]["Healthy"], 0)
+
+	assert.Equal(t, "db", docs[1]["Probe"])
+	assert.InDelta(t, 0, docs[1]["ProbeHealthy"], 0)
+	assert.Contains(t, docs[1], "ProbeLatency")
+
+	meta := docs[1]["_aws"].(map[string]any)
+	directive := meta["CloudWatchMetrics"].([]any)[0].(map[string]any)
+
+	assert.NotZero(t, meta["Timestamp"])
+	assert.Equal(t, "MyApp/Health", directive["Namespace"])
+	assert.Equal(t, []any{[]any{"Service", "Probe"}}, directive["Dimensions"])
+	assert.Equal(t, []any{
+		map[string]any{"Name": "ProbeHealthy", "Unit": "None"},
+		map[string]any{"Name": "ProbeLatency", "Unit": "Milliseconds"},
+	}, directive["Metrics"])
+}
+
+type fakeClient struct {
+	calls []*awscloudwatch.PutMetricDataInput
+	err   error
+}
+
+func (c *fakeClient) PutMetricData(
+	_ context.Context,
+	params *awscloudwatch.PutMetricDataInput,
+	_ ...func(*awscloudwatch.Options),
+) (*awscloudwatch.PutMetricDataOutput, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.calls = append(c.calls, params)
+
+	return &awscloudwatch.PutMetricDataOutput{}, nil
+}
@@ -0,0 +1,122 @@
+package consul
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Option is a functional option for the Consul reporter.
+type Option func(*options) error
+
+type options struct {
+	address         string
+	token           string
+	checkID         string
+	checkName       string
+	ttl             time.Duration
+	deregisterAfter time.Duration
+	client          *http.Client
+	nonCritical     map[string]struct{}
+}
+
+// WithAddress sets the URL of the Consul agent HTTP API. Defaults to the
+// CONSUL_HTTP_ADDR environment variable, or "http://127.0.0.1:8500".
+func WithAddress(address string) Option {
+	return func(o *options) error {
+		if address == "" {
+			return errors.New("address cannot be empty")
+		}
+
+		o.address = address
+
+		return nil
+	}
+}
+
+// WithToken sets the ACL token sent with every request.
+// Defaults to the CONSUL_HTTP_TOKEN environment variable.
+func WithToken(token string) Option {
+	return func(o *options) error {
+		o.token = token
+
+		return nil
+	}
+}
+
+// WithCheckID sets the ID of the registered check.
+// Defaults to "service:<service ID>:health", or "health" for node checks.
+func WithCheckID(id string) Option {
+	return func(o *options) error {
+		if id == "" {
+			return errors.New("check id cannot be empty")
+		}
+
+		o.checkID = id
+
+		return nil
+	}
+}
+
+// WithCheckName sets the name of the registered check.
+// Defaults to "Health".
+func WithCheckName(name string) Option {
+	return func(o *options) error {
+		o.checkName = name
+
+		return nil
+	}
+}
+
+// WithTTL sets the TTL of the registered check, after which Consul marks
+// it as critical unless updated. It must be longer than the period of the
+// Checker, see health.WithPeriod. Defaults to 30 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) error {
+		if ttl < time.Second {
+			return errors.New("ttl must be at least one second")
+		}
+
+		o.ttl = ttl
+
+		return nil
+	}
+}
+
+// WithDeregisterCriticalServiceAfter makes Consul deregister the service
+// once the check has been critical for the given duration, for example
+// when the process is killed before deregistering the check.
+func WithDeregisterCriticalServiceAfter(d time.Duration) Option {
+	return func(o *options) error {
+		o.deregisterAfter = d
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the Consul agent.
+// Defaults to a client with a 10 seconds timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) error {
+		if client == nil {
+			return errors.New("http client cannot be nil")
+		}
+
+		o.client = client
+
+		return nil
+	}
+}
+
+// WithNonCriticalProbes marks the given probes as non-critical. If only
+// non-critical probes are failing, the check is updated as "warning"
+// instead of "critical", which keeps the instance in DNS results.
+func WithNonCriticalProbes(probes ...string) Option {
+	return func(o *options) error {
+		for i := range probes {
+			o.nonCritical[probes[i]] = struct{}{}
+		}
+
+		return nil
+	}
+}
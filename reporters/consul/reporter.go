@@ -0,0 +1,236 @@
+package consul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/summary"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// DefaultAddress is the address of the Consul agent HTTP API used when
+// neither WithAddress nor the CONSUL_HTTP_ADDR environment variable is set.
+const DefaultAddress = "http://127.0.0.1:8500"
+
+// errCheckNotFound is returned when the agent does not know the check,
+// for example because the agent was restarted.
+var errCheckNotFound = errors.New("check not found")
+
+type reporter struct {
+	serviceID string
+	opts      *options
+
+	registered bool
+	closed     bool
+	mu         sync.Mutex
+}
+
+// registration is the body of the /v1/agent/check/register request.
+type registration struct {
+	ID                             string `json:"ID"`
+	Name                           string `json:"Name"`
+	ServiceID                      string `json:"ServiceID,omitempty"`
+	TTL                            string `json:"TTL"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter,omitempty"`
+}
+
+// New creates a new reporter which registers a TTL check for the service
+// with the given ID in the local Consul agent, and updates it on every
+// report using the /v1/agent/check/pass, warn and fail endpoints, with a
+// summary of the probes as the check output. If the service ID is empty,
+// a node-level check is registered instead.
+//
+// The check is registered on the first report, and registered again if the
+// agent forgets it. Once the given context is canceled, the check is
+// deregistered and further reports are ignored.
+func New(ctx context.Context, serviceID string, o ...Option) (health.Reporter, error) {
+	opts := &options{
+		address:     os.Getenv("CONSUL_HTTP_ADDR"),
+		token:       os.Getenv("CONSUL_HTTP_TOKEN"),
+		checkID:     "health",
+		checkName:   "Health",
+		ttl:         30 * time.Second,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		nonCritical: make(map[string]struct{}),
+	}
+
+	if opts.address == "" {
+		opts.address = DefaultAddress
+	}
+
+	if serviceID != "" {
+		opts.checkID = "service:" + serviceID + ":health"
+	}
+
+	for i := range o {
+		if err := o[i](opts); err != nil {
+			return nil, fmt.Errorf("consul: applying option %d failed: %w", i, err)
+		}
+	}
+
+	// The agent accepts addresses without scheme, such as "127.0.0.1:8500".
+	if !strings.Contains(opts.address, "://") {
+		opts.address = "http://" + opts.address
+	}
+
+	opts.address = strings.TrimSuffix(opts.address, "/")
+
+	r := &reporter{serviceID: serviceID, opts: opts}
+
+	go func() {
+		<-ctx.Done()
+
+		deregisterCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := r.deregister(deregisterCtx); err != nil {
+			log.Printf("consul reporter: %v", err)
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	if !r.registered {
+		if err := r.register(ctx); err != nil {
+			return err
+		}
+
+		r.registered = true
+	}
+
+	path := "/v1/agent/check/" + r.result(status) + "/" + url.PathEscape(r.opts.checkID) +
+		"?note=" + url.QueryEscape(summary.Line(status, true))
+
+	err := r.do(ctx, path, nil)
+	if errors.Is(err, errCheckNotFound) {
+		// Register the check again when retried.
+		r.registered = false
+	}
+
+	if err != nil {
+		return fmt.Errorf("consul: updating check failed: %w", err)
+	}
+
+	return nil
+}
+
+// result returns "pass", "warn" or "fail" for the given status.
+func (r *reporter) result(status health.Status) string {
+	result := "pass"
+
+	for name, err := range status.Errors() {
+		if err == nil {
+			continue
+		}
+
+		if _, ok := r.opts.nonCritical[name]; !ok {
+			return "fail"
+		}
+
+		result = "warn"
+	}
+
+	return result
+}
+
+func (r *reporter) register(ctx context.Context) error {
+	reg := registration{
+		ID:        r.opts.checkID,
+		Name:      r.opts.checkName,
+		ServiceID: r.serviceID,
+		TTL:       r.opts.ttl.String(),
+	}
+
+	if r.opts.deregisterAfter > 0 {
+		reg.DeregisterCriticalServiceAfter = r.opts.deregisterAfter.String()
+	}
+
+	body, err := json.Marshal(reg)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("consul: encoding registration failed: %w", err))
+	}
+
+	if err := r.do(ctx, "/v1/agent/check/register", body); err != nil {
+		return fmt.Errorf("consul: registering check failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reporter) deregister(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	if !r.registered {
+		return nil
+	}
+
+	if err := r.do(ctx, "/v1/agent/check/deregister/"+url.PathEscape(r.opts.checkID), nil); err != nil {
+		return fmt.Errorf("deregistering check failed: %w", err)
+	}
+
+	r.registered = false
+
+	return nil
+}
+
+// do sends a PUT request to the agent API. Client errors other than
+// a missing check are permanent, so they are not retried.
+func (r *reporter) do(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.opts.address+path, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+
+	if r.opts.token != "" {
+		req.Header.Set("X-Consul-Token", r.opts.token)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := r.opts.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 4<<10))
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusNotFound, res.StatusCode >= 500 && bytes.Contains(msg, []byte("Unknown check")):
+		// Older agents reply with a 500 status for unknown checks.
+		return errCheckNotFound
+	case res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("unexpected response status %d: %s", res.StatusCode, bytes.TrimSpace(msg))
+	default:
+		return backoff.Permanent(fmt.Errorf("unexpected response status %d: %s", res.StatusCode, bytes.TrimSpace(msg)))
+	}
+}
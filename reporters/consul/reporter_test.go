@@ -0,0 +1,141 @@
+package consul_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/consul"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// agent is a fake Consul agent recording the received requests.
+type agent struct {
+	requests []string
+	bodies   []string
+	tokens   []string
+	checks   map[string]bool
+	mu       sync.Mutex
+}
+
+func newAgent(t *testing.T) (*agent, *httptest.Server) {
+	t.Helper()
+
+	a := &agent{checks: make(map[string]bool)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		body, _ := io.ReadAll(r.Body)
+
+		a.requests = append(a.requests, r.Method+" "+r.URL.RequestURI())
+		a.bodies = append(a.bodies, string(body))
+		a.tokens = append(a.tokens, r.Header.Get("X-Consul-Token"))
+
+		switch r.URL.Path {
+		case "/v1/agent/check/register":
+			reg := struct{ ID string }{}
+			_ = json.Unmarshal(body, &reg)
+			a.checks[reg.ID] = true
+		case "/v1/agent/check/deregister/service:api-1:health":
+			delete(a.checks, "service:api-1:health")
+		default:
+			if !a.checks["service:api-1:health"] {
+				http.Error(w, "Unknown check ID", http.StatusNotFound)
+			}
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return a, srv
+}
+
+func (a *agent) snapshot() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]string(nil), a.requests...)
+}
+
+func TestReporter_Lifecycle(t *testing.T) {
+	a, srv := newAgent(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reporter, err := consul.New(ctx, "api-1",
+		consul.WithAddress(srv.URL),
+		consul.WithToken("secret"),
+		consul.WithTTL(15*time.Second),
+		consul.WithNonCriticalProbes("cache"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", nil)))
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout"))))
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("refused")).Append("cache", nil)))
+
+	assert.Equal(t, []string{
+		"PUT /v1/agent/check/register",
+		"PUT /v1/agent/check/pass/service:api-1:health?note=2%2F2+probes+passing",
+		"PUT /v1/agent/check/warn/service:api-1:health?note=1%2F2+probes+failing%3A+cache+%28timeout%29",
+		"PUT /v1/agent/check/fail/service:api-1:health?note=1%2F2+probes+failing%3A+db+%28refused%29",
+	}, a.snapshot())
+
+	assert.JSONEq(t, `{"ID":"service:api-1:health","Name":"Health","ServiceID":"api-1","TTL":"15s"}`, a.bodies[0])
+	assert.Equal(t, "secret", a.tokens[0])
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		requests := a.snapshot()
+
+		return requests[len(requests)-1] == "PUT /v1/agent/check/deregister/service:api-1:health"
+	}, time.Second, 10*time.Millisecond)
+
+	// Reports after shutdown are ignored.
+	require.NoError(t, reporter.Report(context.Background(), health.NewStatus().Append("db", nil)))
+	assert.Len(t, a.snapshot(), 5)
+}
+
+func TestReporter_RegistersAgainWhenCheckIsLost(t *testing.T) {
+	a, srv := newAgent(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reporter, err := consul.New(ctx, "api-1", consul.WithAddress(srv.URL))
+	require.NoError(t, err)
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	// Simulate an agent restart.
+	a.mu.Lock()
+	a.checks = make(map[string]bool)
+	a.mu.Unlock()
+
+	require.Error(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	assert.Equal(t, []string{
+		"PUT /v1/agent/check/register",
+		"PUT /v1/agent/check/pass/service:api-1:health?note=1%2F1+probes+passing",
+		"PUT /v1/agent/check/pass/service:api-1:health?note=1%2F1+probes+passing",
+		"PUT /v1/agent/check/register",
+		"PUT /v1/agent/check/pass/service:api-1:health?note=1%2F1+probes+passing",
+	}, a.snapshot())
+}
+
+func TestNew_InvalidOption(t *testing.T) {
+	_, err := consul.New(context.Background(), "api-1", consul.WithTTL(time.Millisecond))
+	assert.Error(t, err)
+}
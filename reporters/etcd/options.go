@@ -0,0 +1,21 @@
+package etcd
+
+import "time"
+
+// Option is a functional option for the etcd reporter.
+type Option func(*options)
+
+type options struct {
+	ttl time.Duration
+}
+
+// WithTTL sets the TTL of the lease attached to the key, after which etcd
+// deletes the key unless the lease is refreshed. As the lease is refreshed on
+// every report, it must span several periods of the Checker, see
+// health.WithPeriod, so a late or failed report does not drop the instance.
+// It is rounded up to whole seconds. Defaults to 30 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
@@ -0,0 +1,185 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/cenkalti/backoff/v4"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Client is the subset of the etcd client used by the reporter.
+// It is satisfied by *clientv3.Client.
+type Client interface {
+	Grant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error)
+	KeepAliveOnce(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseKeepAliveResponse, error)
+	Revoke(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error)
+	Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error)
+}
+
+// value is the JSON document stored in the key.
+type value struct {
+	Status string            `json:"status"`
+	Probes map[string]string `json:"probes"`
+}
+
+type reporter struct {
+	client Client
+	key    string
+	opts   *options
+
+	// lease is the lease attached to the key, or clientv3.NoLease
+	// if the key is not present.
+	lease clientv3.LeaseID
+
+	// value is the value last written to the key.
+	value  string
+	closed bool
+	mu     sync.Mutex
+}
+
+// New creates a new reporter which keeps the given key, for example
+// "/services/orders/instance-1", present in etcd only while every probe
+// passes. The key is attached to a lease which is refreshed on every healthy
+// report, and revoked as soon as a probe fails, so watchers of the key prefix
+// observe the membership of healthy instances only. If the process dies, the
+// key is deleted by etcd once the lease expires, see WithTTL.
+//
+// The value of the key is a JSON document with the result of every probe:
+//
+//	{"status":"pass","probes":{"db":"ok"}}
+//
+// Once the given context is canceled, the lease is revoked and further
+// reports are ignored.
+func New(ctx context.Context, client Client, key string, o ...Option) health.Reporter {
+	opts := &options{ttl: 30 * time.Second}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	r := &reporter{
+		client: client,
+		key:    key,
+		opts:   opts,
+		lease:  clientv3.NoLease,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		revokeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.closed = true
+
+		if err := r.revoke(revokeCtx); err != nil {
+			log.Printf("etcd reporter: %v", err)
+		}
+	}()
+
+	return r
+}
+
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	if status.AsError() != nil {
+		return r.revoke(ctx)
+	}
+
+	val, err := encode(status)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("etcd reporter: encoding status failed: %w", err))
+	}
+
+	if r.lease != clientv3.NoLease {
+		// The lease may have expired, for example after a network partition,
+		// in which case the key is gone and a new lease must be granted.
+		// Other errors are retried, as the lease may still be alive.
+		_, err := r.client.KeepAliveOnce(ctx, r.lease)
+
+		switch {
+		case errors.Is(err, rpctypes.ErrLeaseNotFound):
+			r.lease = clientv3.NoLease
+		case err != nil:
+			return fmt.Errorf("etcd reporter: refreshing lease failed: %w", err)
+		}
+	}
+
+	if r.lease == clientv3.NoLease {
+		ttl := int64(math.Ceil(r.opts.ttl.Seconds()))
+
+		res, err := r.client.Grant(ctx, ttl)
+		if err != nil {
+			return fmt.Errorf("etcd reporter: granting lease failed: %w", err)
+		}
+
+		r.lease = res.ID
+		r.value = ""
+	}
+
+	if val == r.value {
+		return nil
+	}
+
+	if _, err := r.client.Put(ctx, r.key, val, clientv3.WithLease(r.lease)); err != nil {
+		return fmt.Errorf("etcd reporter: writing key failed: %w", err)
+	}
+
+	r.value = val
+
+	return nil
+}
+
+// revoke revokes the current lease, if any, deleting the key.
+func (r *reporter) revoke(ctx context.Context) error {
+	if r.lease == clientv3.NoLease {
+		return nil
+	}
+
+	if _, err := r.client.Revoke(ctx, r.lease); err != nil {
+		return fmt.Errorf("etcd reporter: revoking lease failed: %w", err)
+	}
+
+	r.lease = clientv3.NoLease
+	r.value = ""
+
+	return nil
+}
+
+func encode(status health.Status) (string, error) {
+	v := value{Status: "pass", Probes: make(map[string]string)}
+
+	for name, err := range status.Errors() {
+		v.Probes[name] = "ok"
+
+		if err != nil {
+			v.Status = "fail"
+			v.Probes[name] = err.Error()
+		}
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
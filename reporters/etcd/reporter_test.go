@@ -0,0 +1,238 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/etcd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestReporter_Membership(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeClient()
+	reporter := etcd.New(ctx, client, "/services/orders/instance-1", etcd.WithTTL(1500*time.Millisecond))
+	healthy := health.NewStatus().Append("db", nil)
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	val, ttl, ok := client.get("/services/orders/instance-1")
+	require.True(t, ok)
+	assert.JSONEq(t, `{"status":"pass","probes":{"db":"ok"}}`, val)
+	assert.EqualValues(t, 2, ttl)
+
+	// Healthy reports refresh the lease without writing the key again.
+	require.NoError(t, reporter.Report(ctx, healthy))
+	assert.Equal(t, 1, client.count("grant"))
+	assert.Equal(t, 1, client.count("put"))
+	assert.Equal(t, 1, client.count("keepalive"))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("connection refused"))))
+
+	_, _, ok = client.get("/services/orders/instance-1")
+	assert.False(t, ok)
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	_, _, ok = client.get("/services/orders/instance-1")
+	assert.True(t, ok)
+	assert.Equal(t, 2, client.count("grant"))
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		_, _, present := client.get("/services/orders/instance-1")
+
+		return !present
+	}, time.Second, 10*time.Millisecond)
+
+	// Reports after shutdown are ignored.
+	require.NoError(t, reporter.Report(context.Background(), healthy))
+
+	_, _, ok = client.get("/services/orders/instance-1")
+	assert.False(t, ok)
+}
+
+func TestReporter_ExpiredLease(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeClient()
+	reporter := etcd.New(ctx, client, "/services/orders/instance-1")
+	healthy := health.NewStatus().Append("db", nil)
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	client.expireAll()
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	_, ttl, ok := client.get("/services/orders/instance-1")
+	require.True(t, ok)
+	assert.EqualValues(t, 30, ttl)
+	assert.Equal(t, 2, client.count("grant"))
+}
+
+func TestReporter_KeepAliveFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeClient()
+	reporter := etcd.New(ctx, client, "/services/orders/instance-1")
+	healthy := health.NewStatus().Append("db", nil)
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	// Transient errors are retried, keeping the lease and the key.
+	client.failKeepAlive = context.DeadlineExceeded
+	require.ErrorIs(t, reporter.Report(ctx, healthy), context.DeadlineExceeded)
+
+	client.failKeepAlive = nil
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	_, _, ok := client.get("/services/orders/instance-1")
+	assert.True(t, ok)
+	assert.Equal(t, 1, client.count("grant"))
+	assert.Equal(t, 1, client.count("put"))
+}
+
+func TestReporter_GrantFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeClient()
+	client.failGrant = errors.New("etcdserver: request timed out")
+
+	reporter := etcd.New(ctx, client, "/services/orders/instance-1")
+	assert.Error(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+}
+
+// fakeClient is an in-memory implementation of the leases and keys of etcd.
+type fakeClient struct {
+	failGrant     error
+	failKeepAlive error
+
+	next   clientv3.LeaseID
+	leases map[clientv3.LeaseID]int64
+	keys   map[string]clientv3.LeaseID
+	values map[string]string
+	calls  map[string]int
+	mu     sync.Mutex
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		leases: make(map[clientv3.LeaseID]int64),
+		keys:   make(map[string]clientv3.LeaseID),
+		values: make(map[string]string),
+		calls:  make(map[string]int),
+	}
+}
+
+func (c *fakeClient) Grant(_ context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls["grant"]++
+
+	if c.failGrant != nil {
+		return nil, c.failGrant
+	}
+
+	c.next++
+	c.leases[c.next] = ttl
+
+	return &clientv3.LeaseGrantResponse{ID: c.next, TTL: ttl}, nil
+}
+
+func (c *fakeClient) KeepAliveOnce(_ context.Context, id clientv3.LeaseID) (*clientv3.LeaseKeepAliveResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls["keepalive"]++
+
+	if c.failKeepAlive != nil {
+		return nil, c.failKeepAlive
+	}
+
+	ttl, ok := c.leases[id]
+	if !ok {
+		return nil, rpctypes.ErrLeaseNotFound
+	}
+
+	return &clientv3.LeaseKeepAliveResponse{ID: id, TTL: ttl}, nil
+}
+
+func (c *fakeClient) Revoke(_ context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls["revoke"]++
+	c.drop(id)
+
+	return &clientv3.LeaseRevokeResponse{}, nil
+}
+
+func (c *fakeClient) Put(_ context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls["put"]++
+
+	// The lease of the options cannot be inspected, so the key is
+	// attached to the last granted lease, which is the one in use.
+	if _, ok := c.leases[c.next]; !ok || len(opts) != 1 {
+		return nil, rpctypes.ErrLeaseNotFound
+	}
+
+	c.keys[key] = c.next
+	c.values[key] = val
+
+	return &clientv3.PutResponse{}, nil
+}
+
+func (c *fakeClient) expireAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id := range c.leases {
+		c.drop(id)
+	}
+}
+
+// drop deletes the given lease and its keys.
+func (c *fakeClient) drop(id clientv3.LeaseID) {
+	delete(c.leases, id)
+
+	for key, lease := range c.keys {
+		if lease == id {
+			delete(c.keys, key)
+			delete(c.values, key)
+		}
+	}
+}
+
+func (c *fakeClient) get(key string) (string, int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	val, ok := c.values[key]
+
+	return val, c.leases[c.keys[key]], ok
+}
+
+func (c *fakeClient) count(call string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.calls[call]
+}
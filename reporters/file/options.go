@@ -0,0 +1,48 @@
+package file
+
+import "os"
+
+// Format is the content written to the status file.
+type Format int
+
+const (
+	// FormatJSON writes a JSON document with the overall status, the time
+	// of the report and the result of each probe.
+	FormatJSON Format = iota
+
+	// FormatMarker writes "pass" or "fail" followed by a new line.
+	FormatMarker
+)
+
+// Option is a functional option for the file reporter.
+type Option func(*options)
+
+type options struct {
+	format   Format
+	perm     os.FileMode
+	sentinel string
+}
+
+// WithFormat sets the content written to the status file.
+// Defaults to FormatJSON.
+func WithFormat(f Format) Option {
+	return func(o *options) {
+		o.format = f
+	}
+}
+
+// WithFileMode sets the permissions of the status file. Defaults to 0644.
+func WithFileMode(perm os.FileMode) Option {
+	return func(o *options) {
+		o.perm = perm
+	}
+}
+
+// WithSentinel sets the path of a sentinel file which is touched on every
+// report while every probe passes, and removed as soon as any probe fails.
+// Its modification time can be used to detect a stuck Checker.
+func WithSentinel(path string) Option {
+	return func(o *options) {
+		o.sentinel = path
+	}
+}
@@ -0,0 +1,176 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+type fileReporter struct {
+	path string
+	opts *options
+}
+
+// document is the content of the status file in the FormatJSON format.
+type document struct {
+	Status string            `json:"status"`
+	Time   time.Time         `json:"time"`
+	Probes map[string]string `json:"probes"`
+}
+
+// New creates a new reporter which writes the current status to the file
+// at the given path, so it can be read by Kubernetes exec probes or Docker
+// HEALTHCHECK commands without exposing an HTTP port. With FormatMarker, the
+// whole line must be matched, for example:
+//
+//	file.New("/tmp/health", file.WithFormat(file.FormatMarker))
+//
+//	HEALTHCHECK CMD ["grep", "-qx", "pass", "/tmp/health"]
+//
+// The default FormatJSON document holds probe names and errors, so it must be
+// checked by its "status" field instead, for example using jq:
+//
+//	HEALTHCHECK CMD ["jq", "-e", ".status == \"pass\"", "/tmp/health"]
+//
+// The file is replaced atomically on every report, by writing a temporary
+// file in the same directory and renaming it, so readers never observe a
+// partially written file. If the path is empty, only the sentinel file set
+// using WithSentinel is managed.
+func New(path string, o ...Option) health.Reporter {
+	opts := &options{
+		format: FormatJSON,
+		perm:   0o644,
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return &fileReporter{path: path, opts: opts}
+}
+
+func (r *fileReporter) Report(_ context.Context, status health.Status) error {
+	healthy := status.AsError() == nil
+
+	if r.path != "" {
+		content, err := r.render(status, healthy)
+		if err != nil {
+			return fmt.Errorf("file reporter: rendering status failed: %w", err)
+		}
+
+		if err := writeAtomic(r.path, content, r.opts.perm); err != nil {
+			return fmt.Errorf("file reporter: %w", err)
+		}
+	}
+
+	if r.opts.sentinel == "" {
+		return nil
+	}
+
+	if healthy {
+		if err := touch(r.opts.sentinel, r.opts.perm); err != nil {
+			return fmt.Errorf("file reporter: touching sentinel failed: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.Remove(r.opts.sentinel); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("file reporter: removing sentinel failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *fileReporter) render(status health.Status, healthy bool) ([]byte, error) {
+	result := "fail"
+	if healthy {
+		result = "pass"
+	}
+
+	if r.opts.format == FormatMarker {
+		return []byte(result + "\n"), nil
+	}
+
+	doc := document{
+		Status: result,
+		Time:   time.Now().UTC(),
+		Probes: make(map[string]string),
+	}
+
+	for name, err := range status.Errors() {
+		s := "ok"
+		if err != nil {
+			s = err.Error()
+		}
+
+		doc.Probes[name] = s
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
+// writeAtomic replaces the file at the given path with the given content.
+func writeAtomic(path string, content []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file failed: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		return fmt.Errorf("writing temporary file failed: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("syncing temporary file failed: %w", err)
+	}
+
+	if err = tmp.Chmod(perm); err != nil {
+		return fmt.Errorf("setting file mode failed: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary file failed: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("renaming temporary file failed: %w", err)
+	}
+
+	return nil
+}
+
+// touch creates the file at the given path if it does not exist,
+// and sets its modification time to the current time.
+func touch(path string, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return os.Chtimes(path, now, now)
+}
@@ -0,0 +1,92 @@
+package file_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReporter_JSON(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "health.json")
+	reporter := file.New(path, file.WithFileMode(0o600))
+
+	status := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	doc := struct {
+		Status string            `json:"status"`
+		Time   time.Time         `json:"time"`
+		Probes map[string]string `json:"probes"`
+	}{}
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, "fail", doc.Status)
+	assert.False(t, doc.Time.IsZero())
+	assert.Equal(t, map[string]string{"db": "connection refused", "cache": "ok"}, doc.Probes)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files must not be left behind")
+}
+
+func TestFileReporter_MarkerAndSentinel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "health")
+	sentinel := filepath.Join(dir, "healthy")
+	reporter := file.New(path, file.WithFormat(file.FormatMarker), file.WithSentinel(sentinel))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "pass\n", string(content))
+	assert.FileExists(t, sentinel)
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("connection refused"))))
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fail\n", string(content))
+	assert.NoFileExists(t, sentinel)
+
+	// Removing a missing sentinel is not an error.
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("connection refused"))))
+}
+
+func TestFileReporter_SentinelOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sentinel := filepath.Join(t.TempDir(), "healthy")
+	reporter := file.New("", file.WithSentinel(sentinel))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+	assert.FileExists(t, sentinel)
+}
+
+func TestFileReporter_UnwritableDirectory(t *testing.T) {
+	reporter := file.New(filepath.Join(t.TempDir(), "missing", "health"))
+	assert.Error(t, reporter.Report(context.Background(), health.NewStatus().Append("db", nil)))
+}
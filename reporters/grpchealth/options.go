@@ -5,6 +5,7 @@ type Option func(*options)
 
 type options struct {
 	serviceNames []string
+	services     map[string]map[string]struct{}
 	server       HealthServer
 }
 
@@ -19,3 +20,34 @@ func WithServiceNames(names ...string) Option {
 		o.serviceNames = names
 	}
 }
+
+// WithService sets a service whose serving status depends only on the given
+// probes: it is set to SERVING while all of them pass, and to NOT_SERVING as
+// soon as any of them fails. Probes not present in the reported status are
+// ignored. If no probe is provided, the service depends on every probe. This
+// option can be used multiple times to map several services,
+// for example:
+//
+//	grpchealth.New(server,
+//		grpchealth.WithService("orders.v1.OrderService", "postgres"),
+//		grpchealth.WithService("catalog.v1.CatalogService", "redis"),
+//	)
+//
+// Services set using this option are not affected by WithServiceNames. If
+// only this option is used, the overall server status is not reported.
+func WithService(name string, probes ...string) Option {
+	return func(o *options) {
+		if len(probes) == 0 {
+			o.services[name] = nil
+
+			return
+		}
+
+		deps := make(map[string]struct{}, len(probes))
+		for i := range probes {
+			deps[probes[i]] = struct{}{}
+		}
+
+		o.services[name] = deps
+	}
+}
@@ -13,10 +13,14 @@ type proto struct {
 
 // New creates a new reporter that reports health status to the
 // gRPC health server. Use the option WithServiceName to specify
-// the service name to report the status for. If no service name
+// the service name to report the status for, or WithService to
+// map a service to the probes it depends on. If no service name
 // is provided, the status will be reported for the overall server.
 func New(server HealthServer, o ...Option) health.Reporter {
-	opts := &options{server: server}
+	opts := &options{
+		server:   server,
+		services: make(map[string]map[string]struct{}),
+	}
 
 	for i := range o {
 		o[i](opts)
@@ -26,11 +30,28 @@ func New(server HealthServer, o ...Option) health.Reporter {
 }
 
 func (p proto) Report(_ context.Context, status health.Status) error {
-	pbStatus := healthpb.HealthCheckResponse_NOT_SERVING
-	if err := status.AsError(); err == nil {
-		pbStatus = healthpb.HealthCheckResponse_SERVING
+	for name, deps := range p.opts.services {
+		if deps == nil {
+			p.opts.server.SetServingStatus(name, servingStatus(status))
+
+			continue
+		}
+
+		filtered := health.Filter(status, func(probe string) bool {
+			_, ok := deps[probe]
+
+			return ok
+		})
+
+		p.opts.server.SetServingStatus(name, servingStatus(filtered))
 	}
 
+	if len(p.opts.services) > 0 && len(p.opts.serviceNames) == 0 {
+		return nil
+	}
+
+	pbStatus := servingStatus(status)
+
 	if len(p.opts.serviceNames) == 0 {
 		p.opts.server.SetServingStatus("", pbStatus)
 
@@ -43,3 +64,11 @@ func (p proto) Report(_ context.Context, status health.Status) error {
 
 	return nil
 }
+
+func servingStatus(status health.Status) healthpb.HealthCheckResponse_ServingStatus {
+	if err := status.AsError(); err != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
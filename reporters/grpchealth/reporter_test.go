@@ -36,6 +36,61 @@ func TestProtoHealthReporter_ServingStatus(t *testing.T) {
 	require.Equal(t, 1, mockServer.calls)
 }
 
+func TestProtoHealthReporter_PerServiceStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := &mapHealthServer{statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{}}
+	reporter := grpchealth.New(server,
+		grpchealth.WithService("orders.v1.OrderService", "postgres"),
+		grpchealth.WithService("catalog.v1.CatalogService", "redis"),
+	)
+
+	status := health.NewStatus().Append("postgres", errors.New("connection refused")).Append("redis", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	require.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"orders.v1.OrderService":    healthpb.HealthCheckResponse_NOT_SERVING,
+		"catalog.v1.CatalogService": healthpb.HealthCheckResponse_SERVING,
+	}, server.statuses)
+
+	reporter = grpchealth.New(server,
+		grpchealth.WithService("catalog.v1.CatalogService", "redis"),
+		grpchealth.WithServiceNames(""),
+	)
+
+	require.NoError(t, reporter.Report(ctx, status))
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, server.statuses[""])
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, server.statuses["catalog.v1.CatalogService"])
+}
+
+func TestProtoHealthReporter_ServiceWithoutProbes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := &mapHealthServer{statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{}}
+	reporter := grpchealth.New(server,
+		grpchealth.WithService("orders.v1.OrderService"),
+		grpchealth.WithService("catalog.v1.CatalogService", "redis"),
+	)
+
+	status := health.NewStatus().Append("postgres", errors.New("connection refused")).Append("redis", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	require.Equal(t, map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"orders.v1.OrderService":    healthpb.HealthCheckResponse_NOT_SERVING,
+		"catalog.v1.CatalogService": healthpb.HealthCheckResponse_SERVING,
+	}, server.statuses)
+}
+
+type mapHealthServer struct {
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (m *mapHealthServer) SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	m.statuses[service] = status
+}
+
 type mockHealthServer struct {
 	service string
 	status  healthpb.HealthCheckResponse_ServingStatus
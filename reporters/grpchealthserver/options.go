@@ -0,0 +1,33 @@
+package grpchealthserver
+
+// Option is a functional option for the gRPC health server.
+type Option func(*options)
+
+type options struct {
+	services map[string]map[string]struct{}
+}
+
+// WithService registers a gRPC service whose serving status depends on the
+// given probes: the service is SERVING while all of them pass, and
+// NOT_SERVING as soon as any of them fails. If no probe is provided, the
+// service depends on every probe of the Checker.
+//
+// The overall server status, that is the empty service name, is always
+// registered and depends on every probe, unless it is registered using
+// WithService("", probes...).
+func WithService(name string, probes ...string) Option {
+	return func(o *options) {
+		if len(probes) == 0 {
+			o.services[name] = nil
+
+			return
+		}
+
+		deps := make(map[string]struct{}, len(probes))
+		for i := range probes {
+			deps[probes[i]] = struct{}{}
+		}
+
+		o.services[name] = deps
+	}
+}
@@ -0,0 +1,209 @@
+package grpchealthserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/botchris/go-health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Server is an implementation of the gRPC Health Checking Protocol whose
+// service statuses are derived from the health Status of a Checker.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	opts     *options
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+	watchers map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}
+	mu       sync.RWMutex
+}
+
+var _ healthpb.HealthServer = (*Server)(nil)
+
+// New creates a new gRPC health server updated with every Status received
+// from the given channel, typically obtained from Checker.Watch:
+//
+//	srv := grpchealthserver.New(ctx, checker.Watch(),
+//		grpchealthserver.WithService("orders.v1.OrderService", "postgres"),
+//		grpchealthserver.WithService("catalog.v1.CatalogService", "redis"),
+//	)
+//	healthpb.RegisterHealthServer(grpcServer, srv)
+//
+// Services are reported as UNKNOWN until the first Status is received, and
+// as NOT_SERVING once the given context is canceled or the channel is closed.
+func New(ctx context.Context, statuses <-chan health.Status, o ...Option) *Server {
+	opts := &options{services: make(map[string]map[string]struct{})}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	if _, ok := opts.services[""]; !ok {
+		opts.services[""] = nil
+	}
+
+	s := &Server{
+		opts:     opts,
+		statuses: make(map[string]healthpb.HealthCheckResponse_ServingStatus, len(opts.services)),
+		watchers: make(map[string]map[chan healthpb.HealthCheckResponse_ServingStatus]struct{}),
+	}
+
+	for name := range opts.services {
+		s.statuses[name] = healthpb.HealthCheckResponse_UNKNOWN
+	}
+
+	go s.consume(ctx, statuses)
+
+	return s
+}
+
+// Check returns the serving status of the requested service,
+// or a NotFound error if the service is not registered.
+func (s *Server) Check(_ context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, ok := s.statuses[req.GetService()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// List returns the serving status of every registered service.
+func (s *Server) List(_ context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]*healthpb.HealthCheckResponse, len(s.statuses))
+	for name, st := range s.statuses {
+		out[name] = &healthpb.HealthCheckResponse{Status: st}
+	}
+
+	return &healthpb.HealthListResponse{Statuses: out}, nil
+}
+
+// Watch streams the serving status of the requested service, starting with
+// its current status and followed by every transition. Services which are not
+// registered are reported as SERVICE_UNKNOWN.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	service := req.GetService()
+	update := make(chan healthpb.HealthCheckResponse_ServingStatus, 1)
+
+	s.mu.Lock()
+
+	if st, ok := s.statuses[service]; ok {
+		update <- st
+	} else {
+		update <- healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if _, ok := s.watchers[service]; !ok {
+		s.watchers[service] = make(map[chan healthpb.HealthCheckResponse_ServingStatus]struct{})
+	}
+
+	s.watchers[service][update] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers[service], update)
+		s.mu.Unlock()
+	}()
+
+	var last healthpb.HealthCheckResponse_ServingStatus = -1
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case st := <-update:
+			if st == last {
+				continue
+			}
+
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return status.Error(codes.Canceled, "stream has ended")
+			}
+
+			last = st
+		}
+	}
+}
+
+func (s *Server) consume(ctx context.Context, statuses <-chan health.Status) {
+	defer s.setAll(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case st, ok := <-statuses:
+			if !ok {
+				return
+			}
+
+			s.update(st)
+		}
+	}
+}
+
+// update sets the serving status of every service based on the probes
+// it depends on.
+func (s *Server) update(st health.Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, deps := range s.opts.services {
+		filtered := health.Filter(st, func(probe string) bool {
+			if deps == nil {
+				return true
+			}
+
+			_, ok := deps[probe]
+
+			return ok
+		})
+
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if filtered.AsError() != nil {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		s.set(name, servingStatus)
+	}
+}
+
+func (s *Server) setAll(servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name := range s.statuses {
+		s.set(name, servingStatus)
+	}
+}
+
+// set updates the serving status of a service and notifies its watchers.
+// It must be called with the lock held.
+func (s *Server) set(service string, servingStatus healthpb.HealthCheckResponse_ServingStatus) {
+	if s.statuses[service] == servingStatus {
+		return
+	}
+
+	s.statuses[service] = servingStatus
+
+	for update := range s.watchers[service] {
+		// Drop the previous pending update, if any, as only the
+		// latest status is relevant.
+		select {
+		case <-update:
+		default:
+		}
+
+		update <- servingStatus
+	}
+}
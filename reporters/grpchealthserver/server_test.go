@@ -0,0 +1,145 @@
+package grpchealthserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/grpchealthserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	orders  = "orders.v1.OrderService"
+	catalog = "catalog.v1.CatalogService"
+)
+
+func TestServer_PerServiceStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	client := startServer(ctx, t, grpchealthserver.New(ctx, statuses,
+		grpchealthserver.WithService(orders, "postgres"),
+		grpchealthserver.WithService(catalog, "redis"),
+	))
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+
+		return res.GetStatus()
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_UNKNOWN, check(orders))
+
+	statuses <- health.NewStatus().Append("postgres", errors.New("connection refused")).Append("redis", nil)
+
+	require.Eventually(t, func() bool {
+		return check(orders) == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(catalog))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+
+	list, err := client.List(ctx, &healthpb.HealthListRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetStatuses(), 3)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, list.GetStatuses()[catalog].GetStatus())
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_CustomOverallStatus(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	client := startServer(ctx, t, grpchealthserver.New(ctx, statuses,
+		grpchealthserver.WithService("", "postgres"),
+	))
+
+	statuses <- health.NewStatus().Append("postgres", nil).Append("redis", errors.New("timeout"))
+
+	require.Eventually(t, func() bool {
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+		return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestServer_Watch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	client := startServer(ctx, t, grpchealthserver.New(ctx, statuses,
+		grpchealthserver.WithService(orders, "postgres"),
+	))
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: orders})
+	require.NoError(t, err)
+
+	recv := func() healthpb.HealthCheckResponse_ServingStatus {
+		res, rErr := stream.Recv()
+		require.NoError(t, rErr)
+
+		return res.GetStatus()
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_UNKNOWN, recv())
+
+	statuses <- health.NewStatus().Append("postgres", nil)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+
+	// Failures of other probes do not affect the service.
+	statuses <- health.NewStatus().Append("postgres", nil).Append("redis", errors.New("timeout"))
+	statuses <- health.NewStatus().Append("postgres", errors.New("connection refused"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, recv())
+
+	unknown, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.NoError(t, err)
+
+	res, err := unknown.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, res.GetStatus())
+
+	statuses <- health.NewStatus().Append("postgres", nil)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, recv())
+
+	close(statuses)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, recv())
+}
+
+func startServer(ctx context.Context, t *testing.T, srv healthpb.HealthServer) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, srv)
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
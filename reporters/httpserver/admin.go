@@ -0,0 +1,163 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// maxAdminBodySize is the maximum size of the body of admin requests.
+const maxAdminBodySize = 64 << 10
+
+// Overrider manages the manual overrides of probes, see WithAdminEndpoint.
+// It is implemented by *health.Checker.
+type Overrider interface {
+	SetOverride(o health.Override) error
+	ClearOverride(probe string) bool
+	Overrides() []health.Override
+}
+
+var _ Overrider = (*health.Checker)(nil)
+
+// adminEndpoint is the endpoint used to manage manual overrides.
+type adminEndpoint struct {
+	path      string
+	overrider Overrider
+}
+
+// WithAdminEndpoint registers an endpoint at the given path to manage the
+// manual overrides of the given Overrider, usually the Checker, for example
+// to put an instance in maintenance mode:
+//
+//	curl -X POST -H "Authorization: Bearer $ADMIN_TOKEN" \
+//		-d '{"healthy":false,"reason":"draining","duration":"10m"}' \
+//		http://localhost:8081/admin/overrides
+//
+// The endpoint supports the following methods:
+//
+//   - GET lists the active overrides.
+//   - POST sets an override from a JSON object with the "probe", "healthy"
+//     and "reason" fields, and either an "until" time in RFC 3339 format or
+//     a "duration" such as "10m". An empty probe overrides every probe, and
+//     unknown probes are answered with 400 Bad Request.
+//   - DELETE clears the override of the probe given by the "probe" query
+//     parameter, or the one of every probe if omitted.
+//
+// As it changes the reported health, the endpoint is only served to the
+// requests allowed by an admin authorizer, and is answered with 403 Forbidden
+// if none is configured. The authorizers set using WithAuthorizer do not
+// grant access to it. See WithAdminAuthorizer and WithAdminBearerToken.
+func WithAdminEndpoint(path string, o Overrider) Option {
+	return func(opts *options) {
+		opts.admin = &adminEndpoint{path: normalizePath(path), overrider: o}
+	}
+}
+
+// overrideJSON is the JSON representation of a health.Override.
+type overrideJSON struct {
+	Probe    string    `json:"probe,omitempty"`
+	Healthy  bool      `json:"healthy"`
+	Reason   string    `json:"reason"`
+	Until    time.Time `json:"until"`
+	Duration string    `json:"duration,omitempty"`
+}
+
+func (h *Handler) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	if len(h.opts.adminAuthorizers) == 0 {
+		http.Error(w, "admin endpoint requires an admin authorizer", http.StatusForbidden)
+
+		return
+	}
+
+	if !h.adminAuthorized(req) {
+		unauthorized(w, h.opts.adminChallenges)
+
+		return
+	}
+
+	overrider := h.opts.admin.overrider
+
+	switch req.Method {
+	case http.MethodGet:
+		writeOverrides(w, http.StatusOK, overrider.Overrides())
+	case http.MethodPost:
+		o, err := decodeOverride(w, req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err := overrider.SetOverride(o); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		writeOverrides(w, http.StatusOK, overrider.Overrides())
+	case http.MethodDelete:
+		if !overrider.ClearOverride(req.URL.Query().Get("probe")) {
+			http.Error(w, "no active override", http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeOverride reads the override to set from the body of the request.
+func decodeOverride(w http.ResponseWriter, req *http.Request) (health.Override, error) {
+	var in overrideJSON
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxAdminBodySize))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&in); err != nil {
+		return health.Override{}, err
+	}
+
+	o := health.Override{
+		Probe:   in.Probe,
+		Healthy: in.Healthy,
+		Reason:  in.Reason,
+		Until:   in.Until,
+	}
+
+	if in.Duration != "" {
+		d, err := time.ParseDuration(in.Duration)
+		if err != nil {
+			return health.Override{}, err
+		}
+
+		o.Until = time.Now().Add(d)
+	}
+
+	return o, nil
+}
+
+func writeOverrides(w http.ResponseWriter, code int, overrides []health.Override) {
+	out := make([]overrideJSON, 0, len(overrides))
+
+	for _, o := range overrides {
+		out = append(out, overrideJSON{
+			Probe:   o.Probe,
+			Healthy: o.Healthy,
+			Reason:  o.Reason,
+			Until:   o.Until.UTC(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		log.Printf("httpReporter handleAdmin encode error: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Authorizer tells whether the given request is allowed to see the
+// details of the health endpoints, such as probe names and errors.
+type Authorizer func(req *http.Request) bool
+
+// WithAuthorizer adds an Authorizer deciding which requests are allowed to
+// see the details of the health endpoints. When several authorizers are
+// configured, a request is authorized if any of them allows it.
+//
+// Once an authorizer is configured, unauthorized requests are served a
+// minimal body which only tells the health state of the whole endpoint,
+// unless WithAuthRequired is used. Single probe paths and the "exclude"
+// query parameter are ignored for them, so they cannot be used to find out
+// which probes exist or fail.
+func WithAuthorizer(a Authorizer) Option {
+	return func(o *options) {
+		o.authorizers = append(o.authorizers, a)
+	}
+}
+
+// WithBearerToken authorizes requests providing the given token in the
+// "Authorization: Bearer <token>" header. An empty token, for example read
+// from an unset environment variable, authorizes no request. See
+// WithAuthorizer.
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.challenges = append(o.challenges, `Bearer realm="health"`)
+		o.authorizers = append(o.authorizers, bearerToken(token))
+	}
+}
+
+// WithBasicAuth authorizes requests providing the given credentials
+// using HTTP Basic authentication. An empty password authorizes no request.
+// See WithAuthorizer.
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.challenges = append(o.challenges, `Basic realm="health", charset="UTF-8"`)
+		o.authorizers = append(o.authorizers, func(req *http.Request) bool {
+			u, p, ok := req.BasicAuth()
+
+			// Both are compared to not leak which one is wrong through timing.
+			uOK := secureCompare(u, username)
+			pOK := secureCompare(p, password)
+
+			return ok && uOK && pOK && password != ""
+		})
+	}
+}
+
+// WithAuthRequired makes the health endpoints answer unauthorized requests
+// with 401 Unauthorized instead of a minimal body. It has no effect unless
+// an authorizer is configured. See WithAuthorizer.
+func WithAuthRequired() Option {
+	return func(o *options) {
+		o.authRequired = true
+	}
+}
+
+// authorized tells whether the given request is allowed to see the details
+// of the health endpoints. Every request is authorized if no authorizer is
+// configured.
+func (h *Handler) authorized(req *http.Request) bool {
+	if len(h.opts.authorizers) == 0 {
+		return true
+	}
+
+	for _, authorize := range h.opts.authorizers {
+		if authorize(req) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithAdminAuthorizer adds an Authorizer deciding which requests are allowed
+// to use the admin endpoint. When several admin authorizers are configured,
+// a request is authorized if any of them allows it.
+//
+// Admin authorizers are independent of the ones set using WithAuthorizer:
+// being allowed to see the details of the health endpoints does not grant
+// access to the admin endpoint. See WithAdminEndpoint.
+func WithAdminAuthorizer(a Authorizer) Option {
+	return func(o *options) {
+		o.adminAuthorizers = append(o.adminAuthorizers, a)
+	}
+}
+
+// WithAdminBearerToken authorizes requests to the admin endpoint providing
+// the given token in the "Authorization: Bearer <token>" header. An empty
+// token, for example read from an unset environment variable, is ignored, so
+// the admin endpoint is not enabled by it. See WithAdminAuthorizer.
+func WithAdminBearerToken(token string) Option {
+	return func(o *options) {
+		if token == "" {
+			return
+		}
+
+		o.adminChallenges = append(o.adminChallenges, `Bearer realm="health-admin"`)
+		o.adminAuthorizers = append(o.adminAuthorizers, bearerToken(token))
+	}
+}
+
+// bearerToken returns an Authorizer allowing requests which provide the
+// given token in the "Authorization: Bearer <token>" header. No request is
+// allowed if the token is empty.
+func bearerToken(token string) Authorizer {
+	return func(req *http.Request) bool {
+		if token == "" {
+			return false
+		}
+
+		scheme, given, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+
+		return ok && strings.EqualFold(scheme, "Bearer") && secureCompare(given, token)
+	}
+}
+
+// adminAuthorized tells whether the given request is allowed to use the
+// admin endpoint. No request is authorized if no admin authorizer is
+// configured.
+func (h *Handler) adminAuthorized(req *http.Request) bool {
+	for _, authorize := range h.opts.adminAuthorizers {
+		if authorize(req) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unauthorized answers the request with 401 Unauthorized, challenging the
+// client with the given authentication schemes.
+func unauthorized(w http.ResponseWriter, challenges []string) {
+	for _, challenge := range challenges {
+		w.Header().Add("WWW-Authenticate", challenge)
+	}
+
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func secureCompare(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
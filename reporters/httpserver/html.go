@@ -0,0 +1,140 @@
+package httpserver
+
+import (
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// htmlPage is the template of the FormatHTML status page. It is
+// self-contained, so it can be served without any additional asset.
+var htmlPage = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+{{- if .Refresh }}
+<meta http-equiv="refresh" content="{{ .Refresh }}">
+{{- end }}
+<title>{{ .Name }}: {{ .State }}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .5em .75em; border-bottom: 1px solid #ddd; vertical-align: top; }
+th { background: #f5f5f5; }
+.badge { display: inline-block; padding: .15em .6em; border-radius: .3em; color: #fff; font-weight: bold; }
+.healthy, .ok { background: #2e7d32; }
+.degraded, .warn { background: #ed6c02; }
+.unhealthy, .failed { background: #c62828; }
+.starting { background: #757575; }
+.error { font-family: monospace; white-space: pre-wrap; word-break: break-word; }
+footer { margin-top: 1em; color: #757575; font-size: .85em; }
+</style>
+</head>
+<body>
+<h1>{{ .Name }} <span class="badge {{ .State }}">{{ .State }}</span></h1>
+{{- if .Probes }}
+<table>
+<thead><tr><th>Probe</th><th>State</th><th>Latency</th><th>Last change</th><th>Last error</th></tr></thead>
+<tbody>
+{{- range .Probes }}
+<tr>
+<td>{{ .Name }}</td>
+<td><span class="badge {{ .State }}">{{ .State }}</span></td>
+<td>{{ .Latency }}</td>
+<td>{{ .Changed }}</td>
+<td class="error">{{ .Error }}</td>
+</tr>
+{{- end }}
+</tbody>
+</table>
+{{- else if .Hidden }}
+<p>Probe details are only available to authorized clients.</p>
+{{- else }}
+<p>No status reported yet.</p>
+{{- end }}
+{{- if and .Excluded (not .Hidden) }}
+<p>Excluded probes: {{ range $i, $name := .Excluded }}{{ if $i }}, {{ end }}{{ $name }}{{ end }}</p>
+{{- end }}
+<footer>
+{{- if .Updated }}Last updated {{ .Updated }}.{{ end }}
+{{- if .Refresh }} Refreshing every {{ .Refresh }} seconds.{{ end }}
+</footer>
+</body>
+</html>
+`))
+
+// htmlData is the data rendered by the htmlPage template.
+type htmlData struct {
+	Name     string
+	State    state
+	Updated  string
+	Refresh  int
+	Probes   []htmlProbe
+	Excluded []string
+	Hidden   bool
+}
+
+// htmlProbe is the result of a single probe rendered by the htmlPage template.
+type htmlProbe struct {
+	Name    string
+	State   string
+	Latency string
+	Changed string
+	Error   string
+}
+
+// writeHTML renders the report as a self-contained HTML status page.
+func (h *Handler) writeHTML(w http.ResponseWriter, code int, r report) {
+	data := htmlData{
+		Name:     r.name,
+		State:    r.state,
+		Refresh:  int(h.opts.refresh.Seconds()),
+		Excluded: r.excluded,
+		Hidden:   r.hidden && r.status != nil,
+	}
+
+	if !r.updated.IsZero() {
+		data.Updated = r.updated.UTC().Format(time.RFC3339)
+	}
+
+	durations := health.Durations(r.status)
+
+	for _, name := range r.probeNames() {
+		p := htmlProbe{
+			Name:    name,
+			State:   "ok",
+			Latency: durations[name].Round(time.Microsecond).String(),
+		}
+
+		if changed, ok := r.changes[name]; ok {
+			p.Changed = changed.UTC().Format(time.RFC3339)
+		}
+
+		if err := r.status.Errors()[name]; err != nil {
+			p.State = "failed"
+			p.Error = err.Error()
+
+			if _, nonCritical := h.opts.nonCritical[name]; nonCritical {
+				p.State = "warn"
+			}
+		}
+
+		if reason, ok := r.overrideReason(name); ok {
+			p.Error = "overridden: " + reason
+		}
+
+		data.Probes = append(data.Probes, p)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+
+	if err := htmlPage.Execute(w, data); err != nil {
+		log.Printf("httpReporter handleHealth template error: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package httpserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// mediaTypes maps the media types supported in the Accept header to formats.
+var mediaTypes = map[string]Format{
+	"application/json":    FormatJSON,
+	healthJSONContentType: FormatHealthJSON,
+	"text/plain":          FormatText,
+	"text/html":           FormatHTML,
+}
+
+// negotiate selects the output format of the response. The "verbose" query
+// parameter takes precedence, followed by the "format" query parameter and
+// the Accept header of the request, falling back to the format set using
+// WithFormat. It returns false if the "format" query parameter is not a
+// supported format.
+func (h *Handler) negotiate(req *http.Request) (Format, bool) {
+	query := req.URL.Query()
+
+	if query.Has("verbose") {
+		return formatVerbose, true
+	}
+
+	if query.Has("format") {
+		// A literal "+" is decoded as a space in query strings.
+		f := Format(strings.ReplaceAll(query.Get("format"), " ", "+"))
+
+		switch f {
+		case FormatJSON, FormatHealthJSON, FormatText, FormatHTML:
+			return f, true
+		default:
+			return "", false
+		}
+	}
+
+	if f, ok := acceptedFormat(req.Header.Values("Accept")); ok {
+		return f, true
+	}
+
+	return h.opts.format, true
+}
+
+// acceptedFormat returns the supported format with the highest quality in
+// the given Accept header values. Wildcards are not considered, so requests
+// accepting any media type are served using the default format.
+func acceptedFormat(values []string) (Format, bool) {
+	best, bestQ := Format(""), 0.0
+
+	for _, value := range values {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, params, _ := strings.Cut(mediaRange, ";")
+
+			f, ok := mediaTypes[strings.ToLower(strings.TrimSpace(mediaType))]
+			if !ok {
+				continue
+			}
+
+			q := 1.0
+
+			for _, param := range strings.Split(params, ";") {
+				key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if key != "q" {
+					continue
+				}
+
+				if parsed, err := strconv.ParseFloat(val, 64); err == nil {
+					q = parsed
+				}
+			}
+
+			if q > bestQ {
+				best, bestQ = f, q
+			}
+		}
+	}
+
+	return best, bestQ > 0
+}
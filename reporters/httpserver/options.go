@@ -1,24 +1,191 @@
 package httpserver
 
-import "strings"
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+)
 
-// Option is a functional option for httpReporter.
-type Option func(*httpReporter)
+// Option is a functional option for the HTTP reporter.
+type Option func(*options)
+
+type options struct {
+	addr      string
+	path      string
+	endpoints []endpoint
+
+	codes       StatusCodes
+	nonCritical map[string]struct{}
+	format      Format
+	components  map[string]string
+	refresh     time.Duration
+
+	authorizers  []Authorizer
+	challenges   []string
+	authRequired bool
+
+	admin            *adminEndpoint
+	adminAuthorizers []Authorizer
+	adminChallenges  []string
+
+	tlsConfig    *tls.Config
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	listener     net.Listener
+}
 
 // WithAddr sets the bind address for the HTTP server.
+// This option is ignored by NewHandler.
 func WithAddr(addr string) Option {
-	return func(r *httpReporter) {
+	return func(o *options) {
 		if !strings.Contains(addr, ":") {
 			addr = ":" + addr
 		}
 
-		r.addr = addr
+		o.addr = addr
 	}
 }
 
 // WithPath sets the HTTP path for health checks.
 func WithPath(path string) Option {
-	return func(r *httpReporter) {
-		r.path = "/" + strings.TrimPrefix(path, "/")
+	return func(o *options) {
+		o.path = normalizePath(path)
+	}
+}
+
+// WithEndpoint registers an additional health endpoint at the given path
+// which only considers the results of the given probes. If no probe is
+// provided, the endpoint considers every probe of the Checker.
+//
+// This allows exposing Kubernetes-style endpoints, for example:
+//
+//	httpserver.New(ctx,
+//		httpserver.WithEndpoint("/livez", "goroutines"),
+//		httpserver.WithEndpoint("/readyz", "postgres", "redis"),
+//	)
+//
+// Each endpoint also serves the result of individual probes at
+// "<path>/<probe>", and supports the "verbose" and "exclude" query
+// parameters. See New for details.
+func WithEndpoint(path string, probes ...string) Option {
+	return func(o *options) {
+		ep := endpoint{path: normalizePath(path)}
+
+		if len(probes) > 0 {
+			ep.probes = make(map[string]struct{}, len(probes))
+
+			for i := range probes {
+				ep.probes[probes[i]] = struct{}{}
+			}
+		}
+
+		o.endpoints = append(o.endpoints, ep)
 	}
 }
+
+// WithStatusCodes sets the HTTP status codes used to respond for each
+// health state. Zero fields keep their default value.
+func WithStatusCodes(c StatusCodes) Option {
+	return func(o *options) {
+		if c.Starting != 0 {
+			o.codes.Starting = c.Starting
+		}
+
+		if c.Healthy != 0 {
+			o.codes.Healthy = c.Healthy
+		}
+
+		if c.Degraded != 0 {
+			o.codes.Degraded = c.Degraded
+		}
+
+		if c.Unhealthy != 0 {
+			o.codes.Unhealthy = c.Unhealthy
+		}
+	}
+}
+
+// WithNonCriticalProbes marks the given probes as non-critical. When only
+// non-critical probes fail, endpoints are served in a degraded state instead
+// of an unhealthy one. See StatusCodes.
+//
+// Criticality does not apply to single probe endpoints ("<path>/<probe>"),
+// which are always served as unhealthy when the probe fails.
+func WithNonCriticalProbes(probes ...string) Option {
+	return func(o *options) {
+		for i := range probes {
+			o.nonCritical[probes[i]] = struct{}{}
+		}
+	}
+}
+
+// WithFormat sets the output format used when the request does not ask for a
+// specific one. Defaults to FormatJSON.
+func WithFormat(f Format) Option {
+	return func(o *options) {
+		o.format = f
+	}
+}
+
+// WithRefreshInterval sets how often the FormatHTML status page refreshes
+// itself. Values are truncated to whole seconds, and values below 1 second
+// disable the automatic refresh. Defaults to 10 seconds.
+func WithRefreshInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.refresh = d
+	}
+}
+
+// WithComponentType sets the component type reported for the given probes
+// in the FormatHealthJSON output, for example "datastore" or "system".
+// Defaults to "component".
+func WithComponentType(componentType string, probes ...string) Option {
+	return func(o *options) {
+		for i := range probes {
+			o.components[probes[i]] = componentType
+		}
+	}
+}
+
+// WithTLSConfig makes the HTTP server serve HTTPS using the given
+// configuration, which must provide at least one certificate either
+// through Certificates or GetCertificate.
+// This option is ignored by NewHandler.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = cfg
+	}
+}
+
+// WithReadTimeout sets the maximum duration for the HTTP server to read
+// an entire request. Zero or negative values mean no timeout.
+// This option is ignored by NewHandler.
+func WithReadTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.readTimeout = d
+	}
+}
+
+// WithWriteTimeout sets the maximum duration for the HTTP server to
+// write a response. Zero or negative values mean no timeout.
+// This option is ignored by NewHandler.
+func WithWriteTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.writeTimeout = d
+	}
+}
+
+// WithListener makes the HTTP server accept connections on the given
+// listener instead of binding the address set by WithAddr. The listener
+// is closed when the server is shutdown.
+// This option is ignored by NewHandler.
+func WithListener(l net.Listener) Option {
+	return func(o *options) {
+		o.listener = l
+	}
+}
+
+func normalizePath(path string) string {
+	return "/" + strings.Trim(path, "/")
+}
@@ -0,0 +1,303 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// Format is the output format of the health endpoints. It can be
+// requested using the "format" query parameter, for example "?format=html",
+// or using the Accept header with the following media types:
+//
+//   - FormatJSON: "application/json".
+//   - FormatHealthJSON: "application/health+json".
+//   - FormatText: "text/plain".
+//   - FormatHTML: "text/html".
+type Format string
+
+const (
+	// FormatJSON renders a JSON object mapping each probe to either "ok"
+	// or its error message. Until the first status is reported, it renders
+	// {"status":"starting"} instead.
+	FormatJSON Format = "json"
+
+	// FormatHealthJSON renders the "application/health+json" format defined
+	// by the IETF draft "Health Check Response Format for HTTP APIs". It is
+	// also selected when the request accepts "application/health+json".
+	FormatHealthJSON Format = "health+json"
+
+	// FormatText renders a plain text line per probe with its result
+	// and latency.
+	FormatText Format = "text"
+
+	// FormatHTML renders a self-contained HTML status page which
+	// refreshes itself periodically, see WithRefreshInterval.
+	FormatHTML Format = "html"
+
+	// formatVerbose renders the Kubernetes API server verbose format,
+	// selected using the "verbose" query parameter.
+	formatVerbose Format = "verbose"
+)
+
+const (
+	healthJSONContentType = "application/health+json"
+	defaultComponentType  = "component"
+)
+
+// report holds everything needed to render the response of an endpoint.
+type report struct {
+	// name of the endpoint, e.g. "readyz".
+	name  string
+	state state
+
+	// status is the filtered status to be rendered, nil while starting.
+	status health.Status
+
+	// excluded is the sorted list of probes excluded by the request.
+	excluded []string
+
+	// updated is the time the status was reported.
+	updated time.Time
+
+	// changes holds the last time each probe changed between
+	// passing and failing.
+	changes map[string]time.Time
+
+	// hidden tells whether the probe results must be omitted,
+	// as the request is not authorized to see them.
+	hidden bool
+}
+
+// probeNames returns the sorted names of the probes in the report,
+// nil if the probe results are hidden.
+func (r report) probeNames() []string {
+	if r.status == nil || r.hidden {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.status.Errors()))
+
+	for name := range r.status.Errors() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// overrideReason returns the reason of the manual override which forced
+// the given probe into a healthy state, if any. Probes forced into an
+// unhealthy state already carry the reason in their error.
+func (r report) overrideReason(name string) (string, bool) {
+	o, ok := health.Overrides(r.status)[name]
+	if !ok || !o.Healthy {
+		return "", false
+	}
+
+	return o.Reason, true
+}
+
+// writeJSON renders the report as a JSON object mapping each probe to
+// either "ok" or its error message. If the probe results are hidden, or no
+// status was reported yet, the object only holds the health state as "status".
+func writeJSON(w http.ResponseWriter, code int, r report) {
+	out := map[string]string{}
+
+	if r.hidden || r.status == nil {
+		out["status"] = string(r.state)
+	}
+
+	for _, name := range r.probeNames() {
+		s := "ok"
+		if err := r.status.Errors()[name]; err != nil {
+			s = err.Error()
+		}
+
+		out[name] = s
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		log.Printf("httpReporter handleHealth encode error: %v", err)
+	}
+}
+
+// writeText renders the report as a plain text line per probe with its
+// result and latency.
+func writeText(w http.ResponseWriter, code int, r report) {
+	sb := strings.Builder{}
+
+	switch {
+	case r.status == nil:
+		sb.WriteString("no status reported yet\n")
+	case r.hidden:
+		sb.WriteString(r.name + ": " + string(r.state) + "\n")
+	}
+
+	durations := health.Durations(r.status)
+
+	for _, name := range r.probeNames() {
+		latency := durations[name].Round(time.Microsecond)
+
+		if err := r.status.Errors()[name]; err != nil {
+			sb.WriteString(fmt.Sprintf("%s: failed (%s): %s\n", name, latency, err))
+
+			continue
+		}
+
+		if reason, ok := r.overrideReason(name); ok {
+			sb.WriteString(fmt.Sprintf("%s: ok (%s) (overridden: %s)\n", name, latency, reason))
+
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%s: ok (%s)\n", name, latency))
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+
+	if _, err := w.Write([]byte(sb.String())); err != nil {
+		log.Printf("httpReporter handleHealth write error: %v", err)
+	}
+}
+
+// writeVerbose renders the report following the Kubernetes API server
+// verbose format, that is, one line per probe followed by a summary line.
+func writeVerbose(w http.ResponseWriter, code int, r report) {
+	sb := strings.Builder{}
+
+	for _, name := range r.probeNames() {
+		if err := r.status.Errors()[name]; err != nil {
+			sb.WriteString("[-]" + name + " failed: " + err.Error() + "\n")
+
+			continue
+		}
+
+		if reason, ok := r.overrideReason(name); ok {
+			sb.WriteString("[+]" + name + " ok (overridden: " + reason + ")\n")
+
+			continue
+		}
+
+		sb.WriteString("[+]" + name + " ok\n")
+	}
+
+	if !r.hidden {
+		for _, name := range r.excluded {
+			sb.WriteString("[+]" + name + " excluded: ok\n")
+		}
+	}
+
+	switch r.state {
+	case stateStarting:
+		sb.WriteString(r.name + " check pending: no status reported yet\n")
+	case stateHealthy:
+		sb.WriteString(r.name + " check passed\n")
+	case stateDegraded:
+		sb.WriteString(r.name + " check degraded\n")
+	case stateUnhealthy:
+		sb.WriteString(r.name + " check failed\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+
+	if _, err := w.Write([]byte(sb.String())); err != nil {
+		log.Printf("httpReporter handleHealth write error: %v", err)
+	}
+}
+
+// healthJSON is the response document of the FormatHealthJSON format.
+type healthJSON struct {
+	Status      string                       `json:"status"`
+	Description string                       `json:"description,omitempty"`
+	Output      string                       `json:"output,omitempty"`
+	Checks      map[string][]healthJSONCheck `json:"checks,omitempty"`
+}
+
+// healthJSONCheck is the result of a single probe in the FormatHealthJSON format.
+type healthJSONCheck struct {
+	ComponentType string  `json:"componentType"`
+	ObservedValue float64 `json:"observedValue"`
+	ObservedUnit  string  `json:"observedUnit"`
+	Status        string  `json:"status"`
+	Time          string  `json:"time"`
+	Output        string  `json:"output,omitempty"`
+}
+
+// writeHealthJSON renders the report in the FormatHealthJSON format. Each
+// probe is reported as a "<probe>:responseTime" check, whose observed value
+// is the probe latency in milliseconds.
+func (h *Handler) writeHealthJSON(w http.ResponseWriter, code int, r report) {
+	out := healthJSON{
+		Status:      healthJSONStatus(r.state),
+		Description: r.name + " check " + string(r.state),
+		Checks:      make(map[string][]healthJSONCheck),
+	}
+
+	if r.status == nil {
+		out.Output = "no status reported yet"
+	} else {
+		durations := health.Durations(r.status)
+
+		for _, name := range r.probeNames() {
+			check := healthJSONCheck{
+				ComponentType: defaultComponentType,
+				ObservedValue: float64(durations[name].Microseconds()) / 1000,
+				ObservedUnit:  "ms",
+				Status:        "pass",
+				Time:          r.updated.UTC().Format(time.RFC3339),
+			}
+
+			if ct, ok := h.opts.components[name]; ok {
+				check.ComponentType = ct
+			}
+
+			if err := r.status.Errors()[name]; err != nil {
+				check.Status = "fail"
+				check.Output = err.Error()
+
+				if _, nonCritical := h.opts.nonCritical[name]; nonCritical {
+					check.Status = "warn"
+				}
+			}
+
+			if reason, ok := r.overrideReason(name); ok {
+				check.Output = "overridden: " + reason
+			}
+
+			out.Checks[name+":responseTime"] = []healthJSONCheck{check}
+		}
+	}
+
+	w.Header().Set("Content-Type", healthJSONContentType)
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(out); err != nil {
+		log.Printf("httpReporter handleHealth encode error: %v", err)
+	}
+}
+
+// healthJSONStatus maps a health state to the status values defined by the
+// FormatHealthJSON format: "pass", "warn" or "fail".
+func healthJSONStatus(s state) string {
+	switch s {
+	case stateHealthy:
+		return "pass"
+	case stateDegraded:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
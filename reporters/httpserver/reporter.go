@@ -2,109 +2,275 @@ package httpserver
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"log"
 	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/botchris/go-health"
 )
 
-type httpReporter struct {
-	addr string
+// Handler is a health Reporter which serves the last reported status over
+// HTTP. It can be mounted on an existing server or mux, for example:
+//
+//	handler := httpserver.NewHandler(httpserver.WithEndpoint("/readyz", "postgres"))
+//	checker.AddReporter(handler)
+//	mux.Handle("/healthz", handler)
+//	mux.Handle("/readyz/", handler)
+//
+// Following the conventions of the Kubernetes API server, every endpoint:
+//
+//   - Serves the result of a single probe at "<path>/<probe>".
+//   - Accepts the "exclude=<probe>" query parameter, which can be repeated,
+//     to ignore the result of the given probes.
+//   - Accepts the "verbose" query parameter to respond with a plain text
+//     line per probe instead of the JSON document.
+//
+// The output format is negotiated using the "format" query parameter, or
+// the Accept header of the request, see Format. For example, browsers are
+// served an HTML status page. The output format of requests not asking for
+// a supported format can be set using the WithFormat option.
+//
+// Access to the details of the endpoints, such as probe names and errors,
+// can be restricted using WithAuthorizer, WithBearerToken or WithBasicAuth.
+// Manual overrides can be managed over HTTP using WithAdminEndpoint.
+//
+// Responses are served with a status code depending on the health state,
+// see StatusCodes. Until the first status is reported, endpoints are served
+// in a "starting" state. Requests to paths other than the configured
+// endpoints are answered with 404 Not Found.
+type Handler struct {
+	opts *options
+	mux  *http.ServeMux
+
+	last    health.Status
+	updated time.Time
+	changes map[string]probeChange
+	mu      sync.RWMutex
+}
+
+// probeChange records the last time a probe changed between
+// passing and failing.
+type probeChange struct {
+	failing bool
+	since   time.Time
+}
+
+var (
+	_ health.Reporter = (*Handler)(nil)
+	_ http.Handler    = (*Handler)(nil)
+)
+
+// endpoint is a health endpoint which only considers a subset of probes.
+type endpoint struct {
 	path string
 
-	last   health.Status
-	server *http.Server
-	mu     sync.RWMutex
+	// probes is the set of probes considered by the endpoint,
+	// nil means all probes.
+	probes map[string]struct{}
 }
 
-// New creates a new HTTP health reporter.
-// By default, it listens on ":8081" and serves health status at the "/healthz"
-// endpoint. These defaults can be overridden using functional options.
-//
-// The given context is used to manage the lifecycle of the HTTP server.
-// When the context is canceled, the server will be gracefully shutdown.
-func New(ctx context.Context, opts ...Option) health.Reporter {
-	r := &httpReporter{
-		addr: ":8081",
-		path: "/healthz",
+// NewHandler creates a new HTTP health Handler that serves health status
+// at the "/healthz" endpoint by default. Options related to the HTTP server,
+// such as WithAddr or WithTLSConfig, are ignored.
+func NewHandler(o ...Option) *Handler {
+	opts := &options{
+		addr:        ":8081",
+		path:        "/healthz",
+		codes:       defaultStatusCodes,
+		nonCritical: make(map[string]struct{}),
+		format:      FormatJSON,
+		refresh:     10 * time.Second,
+		components:  make(map[string]string),
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	h := &Handler{
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		changes: make(map[string]probeChange),
 	}
 
-	for _, opt := range opts {
-		opt(r)
+	for _, ep := range h.allEndpoints() {
+		h.mux.HandleFunc(ep.path, h.handleHealth(ep))
+		h.mux.HandleFunc(strings.TrimSuffix(ep.path, "/")+"/{probe}", h.handleHealth(ep))
 	}
 
-	r.startServer(ctx)
+	if opts.admin != nil {
+		h.mux.HandleFunc(opts.admin.path, h.handleAdmin)
+	}
 
-	return r
+	return h
 }
 
 // Report saves the last status and makes it available via HTTP.
-func (r *httpReporter) Report(_ context.Context, status health.Status) error {
-	r.mu.Lock()
-	r.last = status
-	r.mu.Unlock()
+func (h *Handler) Report(_ context.Context, status health.Status) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = status
+	h.updated = time.Now()
+
+	for name, err := range status.Errors() {
+		if c, ok := h.changes[name]; !ok || c.failing != (err != nil) {
+			h.changes[name] = probeChange{failing: err != nil, since: h.updated}
+		}
+	}
 
 	return nil
 }
 
-func (r *httpReporter) startServer(ctx context.Context) {
-	h := http.NewServeMux()
-	h.HandleFunc(r.path, r.handleHealth)
+// ServeHTTP serves the health endpoints.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.mux.ServeHTTP(w, req)
+}
 
-	r.server = &http.Server{
-		Addr:    r.addr,
-		Handler: h,
-	}
+func (h *Handler) handleHealth(ep endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		authorized := h.authorized(req)
+		if !authorized && h.opts.authRequired {
+			unauthorized(w, h.opts.challenges)
 
-	go func() {
-		if err := r.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("httpReporter server error: %v", err)
+			return
 		}
-	}()
 
-	go func() {
-		<-ctx.Done()
+		h.mu.RLock()
+		last, updated := h.last, h.updated
+		changes := make(map[string]time.Time, len(h.changes))
 
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		for name, c := range h.changes {
+			changes[name] = c.since
+		}
 
-		if err := r.server.Shutdown(shutdownCtx); err != nil {
-			log.Printf("httpReporter shutdown error: %v", err)
+		h.mu.RUnlock()
+
+		query := req.URL.Query()
+		rep := report{
+			name:    path.Base(ep.path),
+			state:   stateStarting,
+			updated: updated,
+			changes: changes,
+			hidden:  !authorized,
 		}
-	}()
-}
 
-func (r *httpReporter) handleHealth(w http.ResponseWriter, req *http.Request) {
-	r.mu.RLock()
-	status := r.last
-	r.mu.RUnlock()
+		if last != nil {
+			probe, exclude := req.PathValue("probe"), query["exclude"]
+
+			// Unauthorized requests are served the state of the whole
+			// endpoint, so they cannot tell which probes exist or fail.
+			if !authorized {
+				probe, exclude = "", nil
+			}
 
-	stCode := http.StatusOK
+			rep.excluded = ep.excluded(last, exclude)
+			rep.status = health.Filter(last, func(name string) bool {
+				if slices.Contains(rep.excluded, name) {
+					return false
+				}
 
-	if status.AsError() != nil {
-		stCode = http.StatusInternalServerError
+				if probe != "" && name != probe {
+					return false
+				}
+
+				return ep.includes(name)
+			})
+
+			if _, ok := rep.status.Errors()[probe]; probe != "" && !ok {
+				http.NotFound(w, req)
+
+				return
+			}
+
+			rep.state = h.stateOf(rep.status, probe != "")
+		}
+
+		code := h.opts.codes.of(rep.state)
+
+		format, ok := h.negotiate(req)
+		if !ok {
+			http.Error(w, "unsupported format", http.StatusBadRequest)
+
+			return
+		}
+
+		switch format {
+		case formatVerbose:
+			writeVerbose(w, code, rep)
+		case FormatHealthJSON:
+			h.writeHealthJSON(w, code, rep)
+		case FormatText:
+			writeText(w, code, rep)
+		case FormatHTML:
+			h.writeHTML(w, code, rep)
+		default:
+			writeJSON(w, code, rep)
+		}
 	}
+}
 
-	w.WriteHeader(stCode)
-	w.Header().Set("Content-Type", "application/json")
+// stateOf evaluates the health state of the given status. A status whose
+// failing probes are all non-critical is considered degraded, unless strict
+// is set, in which case any failure is considered unhealthy.
+func (h *Handler) stateOf(status health.Status, strict bool) state {
+	if status.AsError() == nil {
+		return stateHealthy
+	}
 
-	report := map[string]string{}
+	if strict {
+		return stateUnhealthy
+	}
 
 	for name, err := range status.Errors() {
-		s := "ok"
-		if err != nil {
-			s = err.Error()
+		if _, nonCritical := h.opts.nonCritical[name]; err != nil && !nonCritical {
+			return stateUnhealthy
 		}
+	}
 
-		report[name] = s
+	return stateDegraded
+}
+
+// allEndpoints returns the default endpoint followed by the ones registered
+// using WithEndpoint. The default endpoint is omitted if another endpoint
+// was registered at the same path.
+func (h *Handler) allEndpoints() []endpoint {
+	for _, ep := range h.opts.endpoints {
+		if ep.path == h.opts.path {
+			return h.opts.endpoints
+		}
+	}
+
+	return append([]endpoint{{path: h.opts.path}}, h.opts.endpoints...)
+}
+
+// excluded returns the sorted list of probes from the given names which
+// are considered by the endpoint and present in the given status.
+func (ep endpoint) excluded(status health.Status, names []string) []string {
+	out := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if _, ok := status.Errors()[name]; ok && ep.includes(name) && !slices.Contains(out, name) {
+			out = append(out, name)
+		}
 	}
 
-	if err := json.NewEncoder(w).Encode(report); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Printf("httpReporter handleHealth encode error: %v", err)
+	sort.Strings(out)
+
+	return out
+}
+
+// includes tells whether the given probe is considered by the endpoint.
+func (ep endpoint) includes(probe string) bool {
+	if ep.probes == nil {
+		return true
 	}
+
+	_, ok := ep.probes[probe]
+
+	return ok
 }
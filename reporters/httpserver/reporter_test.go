@@ -2,10 +2,13 @@ package httpserver_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -73,7 +76,7 @@ func TestHTTPReporter_UnhealthyStatus(t *testing.T) {
 
 	res, err := http.DefaultClient.Do(req)
 	require.NoError(t, err)
-	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
 
 	body, err := io.ReadAll(res.Body)
 	require.NoError(t, err)
@@ -101,3 +104,591 @@ func TestHTTPReporter_CustomPath(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, res.StatusCode)
 }
+
+func TestHTTPReporter_Endpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	addr := getFreePort(t)
+	reporter := httpserver.New(ctx,
+		httpserver.WithAddr(addr),
+		httpserver.WithEndpoint("/livez", "self"),
+		httpserver.WithEndpoint("/readyz", "db", "cache"),
+	)
+
+	time.Sleep(100 * time.Millisecond)
+
+	status := health.NewStatus().
+		Append("self", nil).
+		Append("db", errors.New("connection refused")).
+		Append("cache", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	tests := []struct {
+		name     string
+		path     string
+		code     int
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "default endpoint considers all probes",
+			path:     "/healthz",
+			code:     http.StatusServiceUnavailable,
+			contains: []string{`"self":"ok"`, `"db":"connection refused"`, `"cache":"ok"`},
+		},
+		{
+			name:     "livez only considers its probes",
+			path:     "/livez",
+			code:     http.StatusOK,
+			contains: []string{`"self":"ok"`},
+			excludes: []string{`"db"`, `"cache"`},
+		},
+		{
+			name:     "readyz only considers its probes",
+			path:     "/readyz",
+			code:     http.StatusServiceUnavailable,
+			contains: []string{`"db":"connection refused"`, `"cache":"ok"`},
+			excludes: []string{`"self"`},
+		},
+		{
+			name:     "excluded probes are ignored",
+			path:     "/readyz?exclude=db",
+			code:     http.StatusOK,
+			contains: []string{`"cache":"ok"`},
+			excludes: []string{`"db"`},
+		},
+		{
+			name:     "verbose output",
+			path:     "/readyz?verbose&exclude=cache",
+			code:     http.StatusServiceUnavailable,
+			contains: []string{"[-]db failed: connection refused\n", "[+]cache excluded: ok\n", "readyz check failed\n"},
+		},
+		{
+			name:     "single probe",
+			path:     "/readyz/cache?verbose",
+			code:     http.StatusOK,
+			contains: []string{"[+]cache ok\n", "readyz check passed\n"},
+			excludes: []string{"db"},
+		},
+		{
+			name: "probe not considered by the endpoint",
+			path: "/livez/db",
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+tc.path, nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer func() { _ = res.Body.Close() }()
+
+			assert.Equal(t, tc.code, res.StatusCode)
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			for _, s := range tc.contains {
+				assert.Contains(t, string(body), s)
+			}
+
+			for _, s := range tc.excludes {
+				assert.NotContains(t, string(body), s)
+			}
+		})
+	}
+}
+
+func TestHandler_MountedOnExistingMux(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := httpserver.NewHandler(httpserver.WithEndpoint("/readyz", "db"))
+	require.NoError(t, handler.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", nil)))
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", handler)
+	mux.Handle("/readyz/", handler)
+	mux.HandleFunc("/other", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	for path, code := range map[string]int{
+		"/healthz":   http.StatusOK,
+		"/readyz/db": http.StatusOK,
+		"/other":     http.StatusTeapot,
+	} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+
+		res, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		assert.Equal(t, code, res.StatusCode, path)
+	}
+}
+
+func TestHTTPReporter_ListenerAndTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Borrow the certificate and trusting client of a test TLS server.
+	tlsSrv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsSrv.Close()
+
+	lc := net.ListenConfig{}
+	l, err := lc.Listen(ctx, "tcp", "localhost:0")
+	require.NoError(t, err)
+
+	reporter := httpserver.New(ctx,
+		httpserver.WithListener(l),
+		httpserver.WithTLSConfig(tlsSrv.TLS),
+		httpserver.WithReadTimeout(time.Second),
+		httpserver.WithWriteTimeout(time.Second),
+	)
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+l.Addr().String()+"/healthz", nil)
+	require.NoError(t, err)
+
+	res, err := tlsSrv.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = res.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestHandler_States(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	get := func(t *testing.T, h http.Handler, path string) *http.Response {
+		t.Helper()
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		return rec.Result()
+	}
+
+	t.Run("starting before the first report", func(t *testing.T) {
+		handler := httpserver.NewHandler()
+
+		res := get(t, handler, "/healthz")
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status":"starting"}`, string(body))
+
+		res = get(t, handler, "/healthz/db?verbose")
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "text/plain; charset=utf-8", res.Header.Get("Content-Type"))
+
+		body, err = io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "healthz check pending: no status reported yet\n", string(body))
+	})
+
+	t.Run("degraded when only non-critical probes fail", func(t *testing.T) {
+		handler := httpserver.NewHandler(httpserver.WithNonCriticalProbes("cache"))
+		status := health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout"))
+		require.NoError(t, handler.Report(ctx, status))
+
+		assert.Equal(t, http.StatusOK, get(t, handler, "/healthz").StatusCode)
+		assert.Equal(t, http.StatusServiceUnavailable, get(t, handler, "/healthz/cache").StatusCode)
+	})
+
+	t.Run("custom status codes", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithNonCriticalProbes("cache"),
+			httpserver.WithStatusCodes(httpserver.StatusCodes{
+				Starting:  http.StatusTooEarly,
+				Degraded:  http.StatusMultiStatus,
+				Unhealthy: http.StatusInternalServerError,
+			}),
+		)
+
+		assert.Equal(t, http.StatusTooEarly, get(t, handler, "/healthz").StatusCode)
+
+		require.NoError(t, handler.Report(ctx, health.NewStatus().Append("db", nil)))
+		assert.Equal(t, http.StatusOK, get(t, handler, "/healthz").StatusCode)
+
+		require.NoError(t, handler.Report(ctx, health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout"))))
+		assert.Equal(t, http.StatusMultiStatus, get(t, handler, "/healthz").StatusCode)
+
+		require.NoError(t, handler.Report(ctx, health.NewStatus().Append("db", errors.New("refused"))))
+		assert.Equal(t, http.StatusInternalServerError, get(t, handler, "/healthz").StatusCode)
+	})
+}
+
+func TestHandler_HealthJSONFormat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type check struct {
+		ComponentType string  `json:"componentType"`
+		ObservedValue float64 `json:"observedValue"`
+		ObservedUnit  string  `json:"observedUnit"`
+		Status        string  `json:"status"`
+		Time          string  `json:"time"`
+		Output        string  `json:"output"`
+	}
+
+	type document struct {
+		Status string             `json:"status"`
+		Output string             `json:"output"`
+		Checks map[string][]check `json:"checks"`
+	}
+
+	serve := func(t *testing.T, h http.Handler, accept string) (*http.Response, document) {
+		t.Helper()
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/healthz", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		doc := document{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&doc))
+
+		return rec.Result(), doc
+	}
+
+	t.Run("selected by the accept header", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithNonCriticalProbes("cache"),
+			httpserver.WithComponentType("datastore", "db"),
+		)
+
+		res, doc := serve(t, handler, "application/health+json")
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "application/health+json", res.Header.Get("Content-Type"))
+		assert.Equal(t, "fail", doc.Status)
+		assert.NotEmpty(t, doc.Output)
+
+		status := health.NewStatus().Append("db", nil).Append("cache", errors.New("timeout"))
+		require.NoError(t, handler.Report(ctx, status))
+
+		res, doc = serve(t, handler, "text/html;q=0.9, application/health+json")
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "warn", doc.Status)
+		require.Len(t, doc.Checks["db:responseTime"], 1)
+		require.Len(t, doc.Checks["cache:responseTime"], 1)
+
+		db := doc.Checks["db:responseTime"][0]
+		assert.Equal(t, "datastore", db.ComponentType)
+		assert.Equal(t, "pass", db.Status)
+		assert.Equal(t, "ms", db.ObservedUnit)
+		assert.NotEmpty(t, db.Time)
+
+		cache := doc.Checks["cache:responseTime"][0]
+		assert.Equal(t, "component", cache.ComponentType)
+		assert.Equal(t, "warn", cache.Status)
+		assert.Equal(t, "timeout", cache.Output)
+	})
+
+	t.Run("selected by option", func(t *testing.T) {
+		handler := httpserver.NewHandler(httpserver.WithFormat(httpserver.FormatHealthJSON))
+		require.NoError(t, handler.Report(ctx, health.NewStatus().Append("db", errors.New("refused"))))
+
+		res, doc := serve(t, handler, "")
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Equal(t, "fail", doc.Status)
+		assert.Equal(t, "fail", doc.Checks["db:responseTime"][0].Status)
+	})
+}
+
+func TestHandler_ContentNegotiation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := httpserver.NewHandler(httpserver.WithRefreshInterval(5 * time.Second))
+	status := health.NewStatus().Append("db", nil).Append("cache", errors.New("connection <timeout>"))
+	require.NoError(t, handler.Report(ctx, status))
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		code        int
+		contentType string
+		contains    []string
+	}{
+		{
+			name:        "any media type uses the default format",
+			path:        "/healthz",
+			accept:      "*/*",
+			code:        http.StatusServiceUnavailable,
+			contentType: "application/json",
+			contains:    []string{`"db":"ok"`},
+		},
+		{
+			name:        "plain text",
+			path:        "/healthz",
+			accept:      "text/plain",
+			code:        http.StatusServiceUnavailable,
+			contentType: "text/plain; charset=utf-8",
+			contains:    []string{"cache: failed (", "): connection <timeout>\n", "db: ok ("},
+		},
+		{
+			name:        "browsers get the html page",
+			path:        "/healthz",
+			accept:      "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			code:        http.StatusServiceUnavailable,
+			contentType: "text/html; charset=utf-8",
+			contains: []string{
+				`<meta http-equiv="refresh" content="5">`,
+				`<span class="badge unhealthy">unhealthy</span>`,
+				"connection &lt;timeout&gt;",
+				"Last updated ",
+			},
+		},
+		{
+			name:        "accept quality is honored",
+			path:        "/healthz",
+			accept:      "text/html;q=0.5, text/plain",
+			code:        http.StatusServiceUnavailable,
+			contentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:        "format query parameter takes precedence",
+			path:        "/healthz?format=health%2Bjson",
+			accept:      "text/html",
+			code:        http.StatusServiceUnavailable,
+			contentType: "application/health+json",
+		},
+		{
+			name: "unsupported format",
+			path: "/healthz?format=xml",
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, tc.path, nil)
+			req.Header.Set("Accept", tc.accept)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.code, rec.Code)
+
+			if tc.contentType != "" {
+				assert.Equal(t, tc.contentType, rec.Header().Get("Content-Type"))
+			}
+
+			for _, s := range tc.contains {
+				assert.Contains(t, rec.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestHandler_AccessControl(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	status := health.NewStatus().Append("db", errors.New("dial tcp db.internal:5432: connection refused"))
+
+	serve := func(t *testing.T, h http.Handler, path string, auth func(req *http.Request)) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		if auth != nil {
+			auth(req)
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	bearer := func(token string) func(req *http.Request) {
+		return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+
+	t.Run("unauthenticated callers get a minimal body", func(t *testing.T) {
+		handler := httpserver.NewHandler(httpserver.WithBearerToken("s3cr3t"))
+		require.NoError(t, handler.Report(ctx, status))
+
+		for _, path := range []string{"/healthz", "/healthz?verbose", "/healthz?format=text", "/healthz?format=html", "/healthz?format=health%2Bjson"} {
+			rec := serve(t, handler, path, bearer("wrong"))
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+			assert.NotContains(t, rec.Body.String(), "db", path)
+		}
+
+		rec := serve(t, handler, "/healthz", nil)
+		assert.JSONEq(t, `{"status":"unhealthy"}`, rec.Body.String())
+
+		// Probe paths and exclusions must not reveal which probes exist or fail.
+		for _, path := range []string{"/healthz/db", "/healthz/unknown", "/healthz?exclude=db"} {
+			rec := serve(t, handler, path, nil)
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code, path)
+			assert.JSONEq(t, `{"status":"unhealthy"}`, rec.Body.String(), path)
+		}
+	})
+
+	t.Run("authenticated callers get full details", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithBearerToken("s3cr3t"),
+			httpserver.WithBasicAuth("admin", "passw0rd"),
+		)
+		require.NoError(t, handler.Report(ctx, status))
+
+		rec := serve(t, handler, "/healthz", bearer("s3cr3t"))
+		assert.Contains(t, rec.Body.String(), "db.internal")
+
+		rec = serve(t, handler, "/healthz", func(req *http.Request) { req.SetBasicAuth("admin", "passw0rd") })
+		assert.Contains(t, rec.Body.String(), "db.internal")
+
+		rec = serve(t, handler, "/healthz", func(req *http.Request) { req.SetBasicAuth("admin", "wrong") })
+		assert.NotContains(t, rec.Body.String(), "db.internal")
+	})
+
+	t.Run("empty credentials authorize no request", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithBearerToken(""),
+			httpserver.WithBasicAuth("admin", ""),
+		)
+		require.NoError(t, handler.Report(ctx, status))
+
+		for _, authenticate := range []func(req *http.Request){
+			nil,
+			bearer(""),
+			func(req *http.Request) { req.SetBasicAuth("admin", "") },
+		} {
+			rec := serve(t, handler, "/healthz", authenticate)
+			assert.JSONEq(t, `{"status":"unhealthy"}`, rec.Body.String())
+		}
+	})
+
+	t.Run("custom authorizer and required authentication", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithBasicAuth("admin", "passw0rd"),
+			httpserver.WithAuthorizer(func(req *http.Request) bool {
+				return req.Header.Get("X-Internal") == "true"
+			}),
+			httpserver.WithAuthRequired(),
+		)
+		require.NoError(t, handler.Report(ctx, status))
+
+		rec := serve(t, handler, "/healthz", nil)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Basic realm="health", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+		assert.NotContains(t, rec.Body.String(), "db")
+
+		rec = serve(t, handler, "/healthz", func(req *http.Request) { req.Header.Set("X-Internal", "true") })
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), "db.internal")
+	})
+}
+
+func TestHandler_AdminEndpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checker, err := health.NewChecker(health.WithPeriod(50 * time.Millisecond))
+	require.NoError(t, err)
+
+	checker.AddProbe("db", health.ProbeFunc(func(context.Context) error { return errors.New("connection refused") }))
+
+	handler := httpserver.NewHandler(
+		httpserver.WithBearerToken("s3cr3t"),
+		httpserver.WithAdminBearerToken("4dm1n"),
+		httpserver.WithAdminEndpoint("/admin/overrides", checker),
+	)
+	checker.AddReporter(handler)
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := serve(http.MethodPost, "/admin/overrides", `{"probe":"db","healthy":true,"reason":"failover","duration":"1m"}`, "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="health-admin"`, rec.Header().Get("WWW-Authenticate"))
+
+	rec = serve(http.MethodPost, "/admin/overrides", `{"probe":"db","healthy":true,"reason":"failover","duration":"1m"}`, "s3cr3t")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "read-only tokens must not grant admin access")
+
+	rec = serve(http.MethodPost, "/admin/overrides", `{"probe":"db","healthy":true,"duration":"1m"}`, "4dm1n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(http.MethodPost, "/admin/overrides", `{"probe":"DB","healthy":true,"reason":"failover","duration":"1m"}`, "4dm1n")
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "unknown probes must be rejected")
+
+	rec = serve(http.MethodPost, "/admin/overrides", `{"probe":"db","healthy":true,"reason":"failover","duration":"1m"}`, "4dm1n")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"reason":"failover"`)
+
+	checker.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return serve(http.MethodGet, "/healthz", "", "s3cr3t").Code == http.StatusOK
+	}, 2*time.Second, 10*time.Millisecond)
+
+	rec = serve(http.MethodGet, "/healthz?verbose", "", "s3cr3t")
+	assert.Contains(t, rec.Body.String(), "[+]db ok (overridden: failover)")
+
+	rec = serve(http.MethodGet, "/admin/overrides", "", "4dm1n")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var overrides []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &overrides))
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "db", overrides[0]["probe"])
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/admin/overrides?probe=db", "", "4dm1n").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/admin/overrides?probe=db", "", "4dm1n").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPut, "/admin/overrides", "", "4dm1n").Code)
+
+	t.Run("requires an admin authorizer", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithBearerToken("s3cr3t"),
+			httpserver.WithAdminEndpoint("/admin/overrides", checker),
+		)
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/admin/overrides", nil)
+		req.Header.Set("Authorization", "Bearer s3cr3t")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("ignores an empty admin token", func(t *testing.T) {
+		handler := httpserver.NewHandler(
+			httpserver.WithAdminBearerToken(""),
+			httpserver.WithAdminEndpoint("/admin/overrides", checker),
+		)
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/admin/overrides", nil)
+		req.Header.Set("Authorization", "Bearer ")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,68 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// New creates a new HTTP health reporter running its own HTTP server.
+// By default, it listens on ":8081" and serves health status at the "/healthz"
+// endpoint. These defaults can be overridden using functional options.
+// Additional endpoints restricted to a subset of probes can be registered using
+// the WithEndpoint option. See Handler for details on the served endpoints.
+//
+// Use NewHandler instead to mount the health endpoints on an existing server.
+//
+// The given context is used to manage the lifecycle of the HTTP server.
+// When the context is canceled, the server will be gracefully shutdown.
+func New(ctx context.Context, opts ...Option) health.Reporter {
+	h := NewHandler(opts...)
+	h.startServer(ctx)
+
+	return h
+}
+
+func (h *Handler) startServer(ctx context.Context) {
+	server := &http.Server{
+		Addr:         h.opts.addr,
+		Handler:      h,
+		TLSConfig:    h.opts.tlsConfig,
+		ReadTimeout:  h.opts.readTimeout,
+		WriteTimeout: h.opts.writeTimeout,
+	}
+
+	go func() {
+		if err := serve(server, h.opts); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("httpReporter server error: %v", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("httpReporter shutdown error: %v", err)
+		}
+	}()
+}
+
+func serve(server *http.Server, opts *options) error {
+	switch {
+	case opts.listener != nil && opts.tlsConfig != nil:
+		return server.ServeTLS(opts.listener, "", "")
+	case opts.listener != nil:
+		return server.Serve(opts.listener)
+	case opts.tlsConfig != nil:
+		return server.ListenAndServeTLS("", "")
+	default:
+		return server.ListenAndServe()
+	}
+}
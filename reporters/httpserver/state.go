@@ -0,0 +1,54 @@
+package httpserver
+
+import "net/http"
+
+// state is the health state served by the reporter.
+type state string
+
+const (
+	// stateStarting is served until the first status is reported.
+	stateStarting state = "starting"
+
+	// stateHealthy is served when every probe passes.
+	stateHealthy state = "healthy"
+
+	// stateDegraded is served when only non-critical probes fail.
+	stateDegraded state = "degraded"
+
+	// stateUnhealthy is served when at least one critical probe fails.
+	stateUnhealthy state = "unhealthy"
+)
+
+// StatusCodes defines the HTTP status code used to respond for each health state.
+// Zero values are replaced with the defaults.
+//
+//   - Starting: used until the first status is reported, defaults to 503.
+//   - Healthy: used when every probe passes, defaults to 200.
+//   - Degraded: used when only non-critical probes fail, defaults to 200.
+//   - Unhealthy: used when any critical probe fails, defaults to 503.
+type StatusCodes struct {
+	Starting  int
+	Healthy   int
+	Degraded  int
+	Unhealthy int
+}
+
+var defaultStatusCodes = StatusCodes{
+	Starting:  http.StatusServiceUnavailable,
+	Healthy:   http.StatusOK,
+	Degraded:  http.StatusOK,
+	Unhealthy: http.StatusServiceUnavailable,
+}
+
+func (c StatusCodes) of(s state) int {
+	switch s {
+	case stateHealthy:
+		return c.Healthy
+	case stateDegraded:
+		return c.Degraded
+	case stateUnhealthy:
+		return c.Unhealthy
+	default:
+		return c.Starting
+	}
+}
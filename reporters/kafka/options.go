@@ -0,0 +1,70 @@
+package kafka
+
+// Encoding is the encoding of the message values.
+type Encoding int
+
+const (
+	// EncodingJSON encodes the value as an Event JSON document, holding
+	// the result of every probe and the probes which changed.
+	EncodingJSON Encoding = iota
+
+	// EncodingCompact encodes the value as a CompactEvent JSON document,
+	// holding only the failing probes.
+	EncodingCompact
+)
+
+// Delivery is the delivery semantics of the messages.
+type Delivery int
+
+const (
+	// DeliveryAtLeastOnce returns the errors of the producer to the Checker,
+	// so the message is produced again when the report is retried. Consumers
+	// may receive duplicated messages.
+	DeliveryAtLeastOnce Delivery = iota
+
+	// DeliveryAtMostOnce ignores the errors of the producer, so a transition
+	// is dropped if its message cannot be produced. Consumers never receive
+	// duplicated messages.
+	DeliveryAtMostOnce
+)
+
+// Option is a functional option for the Kafka reporter.
+type Option func(*options)
+
+type options struct {
+	service  string
+	instance string
+	encoding Encoding
+	delivery Delivery
+}
+
+// WithService sets the name of the service, used in the message key.
+func WithService(service string) Option {
+	return func(o *options) {
+		o.service = service
+	}
+}
+
+// WithInstance sets the instance, used in the message key.
+// Defaults to the hostname.
+func WithInstance(instance string) Option {
+	return func(o *options) {
+		o.instance = instance
+	}
+}
+
+// WithEncoding sets the encoding of the message values.
+// Defaults to EncodingJSON.
+func WithEncoding(e Encoding) Option {
+	return func(o *options) {
+		o.encoding = e
+	}
+}
+
+// WithDelivery sets the delivery semantics of the messages.
+// Defaults to DeliveryAtLeastOnce.
+func WithDelivery(d Delivery) Option {
+	return func(o *options) {
+		o.delivery = d
+	}
+}
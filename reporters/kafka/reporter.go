@@ -0,0 +1,216 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Content types of the message values, set in the "content-type" header.
+const (
+	ContentTypeJSON    = "application/vnd.go-health.event+json"
+	ContentTypeCompact = "application/vnd.go-health.compact+json"
+)
+
+// Message is a record to be produced to a Kafka topic.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Producer produces messages to Kafka. It is implemented by adapting the
+// producer of a Kafka client library, such as franz-go, sarama or
+// confluent-kafka-go. Produce must only return once the messages are
+// acknowledged by the brokers, so DeliveryAtLeastOnce can retry them.
+type Producer interface {
+	Produce(ctx context.Context, msgs ...Message) error
+}
+
+// Event is the value of the messages in the EncodingJSON encoding.
+type Event struct {
+	Service  string    `json:"service,omitempty"`
+	Instance string    `json:"instance"`
+	Time     time.Time `json:"time"`
+	Healthy  bool      `json:"healthy"`
+
+	// Probes holds the result of every probe, sorted by name.
+	Probes []Probe `json:"probes"`
+
+	// Changed holds the names of the probes whose state changed, sorted.
+	Changed []string `json:"changed"`
+}
+
+// Probe is the result of a single probe within an Event.
+type Probe struct {
+	Name     string  `json:"name"`
+	Healthy  bool    `json:"healthy"`
+	Error    string  `json:"error,omitempty"`
+	Duration float64 `json:"durationMs"`
+}
+
+// CompactEvent is the value of the messages in the EncodingCompact encoding.
+// The service and instance are only present in the message key.
+type CompactEvent struct {
+	// Time is the time of the transition, in milliseconds since the epoch.
+	Time int64 `json:"t"`
+
+	// Healthy tells whether every probe is passing.
+	Healthy bool `json:"h"`
+
+	// Failing maps each failing probe to its error.
+	Failing map[string]string `json:"f,omitempty"`
+}
+
+type reporter struct {
+	producer Producer
+	topic    string
+	opts     *options
+
+	tracker transition.Tracker
+	mu      sync.Mutex
+}
+
+// New creates a new reporter which produces a message to the given topic every
+// time a probe transitions between passing and failing. Probes seen for the
+// first time are only considered a transition if failing. Messages are keyed
+// by "<service>/<instance>", so the transitions of an instance are kept in
+// order within a partition, and compacted topics retain the latest status of
+// every instance.
+func New(producer Producer, topic string, o ...Option) health.Reporter {
+	opts := &options{}
+
+	if hostname, err := os.Hostname(); err == nil {
+		opts.instance = hostname
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return &reporter{producer: producer, topic: topic, opts: opts}
+}
+
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, state := r.transition(status)
+	if event == nil {
+		return nil
+	}
+
+	msg, err := r.message(event)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("kafka reporter: encoding message failed: %w", err))
+	}
+
+	if err := r.producer.Produce(ctx, msg); err != nil {
+		if r.opts.delivery == DeliveryAtLeastOnce {
+			return fmt.Errorf("kafka reporter: producing message failed: %w", err)
+		}
+
+		log.Printf("kafka reporter: dropping transition, producing message failed: %v", err)
+	}
+
+	r.tracker.Commit(state)
+
+	return nil
+}
+
+// transition returns the event to be produced for the given status, or nil if
+// no probe changed its state since the last produced message. It also returns
+// the state to be committed once the message is produced.
+func (r *reporter) transition(status health.Status) (*Event, transition.State) {
+	changes, state := r.tracker.Diff(status)
+	if len(changes) == 0 {
+		return nil, state
+	}
+
+	errs := status.Errors()
+	durations := health.Durations(status)
+	event := &Event{
+		Service:  r.opts.service,
+		Instance: r.opts.instance,
+		Time:     time.Now(),
+		Healthy:  status.AsError() == nil,
+	}
+
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := Probe{
+			Name:     name,
+			Healthy:  errs[name] == nil,
+			Duration: float64(durations[name].Microseconds()) / 1000,
+		}
+
+		if errs[name] != nil {
+			p.Error = errs[name].Error()
+		}
+
+		event.Probes = append(event.Probes, p)
+	}
+
+	for _, c := range changes {
+		event.Changed = append(event.Changed, c.Probe)
+	}
+
+	return event, state
+}
+
+func (r *reporter) message(event *Event) (Message, error) {
+	var (
+		value       []byte
+		err         error
+		contentType = ContentTypeJSON
+	)
+
+	switch r.opts.encoding {
+	case EncodingCompact:
+		contentType = ContentTypeCompact
+		compact := CompactEvent{Time: event.Time.UnixMilli(), Healthy: event.Healthy}
+
+		for _, p := range event.Probes {
+			if p.Healthy {
+				continue
+			}
+
+			if compact.Failing == nil {
+				compact.Failing = make(map[string]string)
+			}
+
+			compact.Failing[p.Name] = p.Error
+		}
+
+		value, err = json.Marshal(compact)
+	default:
+		value, err = json.Marshal(event)
+	}
+
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		Topic:   r.topic,
+		Key:     []byte(r.opts.service + "/" + r.opts.instance),
+		Value:   value,
+		Headers: map[string]string{"content-type": contentType},
+	}, nil
+}
@@ -0,0 +1,111 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_ProducesTransitions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	producer := &fakeProducer{}
+	reporter := kafka.New(producer, "health-events", kafka.WithService("orders"), kafka.WithInstance("orders-1"))
+
+	healthy := health.NewStatus().Append("db", nil).Append("cache", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+
+	// Passing probes seen for the first time are not a transition.
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.Len(t, producer.messages, 2)
+
+	msg := producer.messages[0]
+	assert.Equal(t, "health-events", msg.Topic)
+	assert.Equal(t, "orders/orders-1", string(msg.Key))
+	assert.Equal(t, kafka.ContentTypeJSON, msg.Headers["content-type"])
+
+	var event kafka.Event
+
+	require.NoError(t, json.Unmarshal(msg.Value, &event))
+	assert.Equal(t, "orders", event.Service)
+	assert.Equal(t, "orders-1", event.Instance)
+	assert.False(t, event.Healthy)
+	assert.Equal(t, []string{"db"}, event.Changed)
+	require.Len(t, event.Probes, 2)
+	assert.Equal(t, "cache", event.Probes[0].Name)
+	assert.Equal(t, "connection refused", event.Probes[1].Error)
+
+	require.NoError(t, json.Unmarshal(producer.messages[1].Value, &event))
+	assert.True(t, event.Healthy)
+}
+
+func TestReporter_CompactEncoding(t *testing.T) {
+	producer := &fakeProducer{}
+	reporter := kafka.New(producer, "health-events", kafka.WithEncoding(kafka.EncodingCompact))
+
+	status := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+	require.NoError(t, reporter.Report(context.Background(), status))
+	require.Len(t, producer.messages, 1)
+
+	var event kafka.CompactEvent
+
+	require.NoError(t, json.Unmarshal(producer.messages[0].Value, &event))
+	assert.False(t, event.Healthy)
+	assert.NotZero(t, event.Time)
+	assert.Equal(t, map[string]string{"db": "connection refused"}, event.Failing)
+	assert.Equal(t, kafka.ContentTypeCompact, producer.messages[0].Headers["content-type"])
+}
+
+func TestReporter_DeliverySemantics(t *testing.T) {
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	t.Run("at least once", func(t *testing.T) {
+		producer := &fakeProducer{err: errors.New("not enough replicas")}
+		reporter := kafka.New(producer, "health-events")
+
+		require.Error(t, reporter.Report(context.Background(), unhealthy))
+
+		producer.err = nil
+
+		require.NoError(t, reporter.Report(context.Background(), unhealthy))
+		assert.Len(t, producer.messages, 1)
+	})
+
+	t.Run("at most once", func(t *testing.T) {
+		producer := &fakeProducer{err: errors.New("not enough replicas")}
+		reporter := kafka.New(producer, "health-events", kafka.WithDelivery(kafka.DeliveryAtMostOnce))
+
+		require.NoError(t, reporter.Report(context.Background(), unhealthy))
+
+		producer.err = nil
+
+		require.NoError(t, reporter.Report(context.Background(), unhealthy))
+		assert.Empty(t, producer.messages)
+	})
+}
+
+type fakeProducer struct {
+	messages []kafka.Message
+	err      error
+}
+
+func (p *fakeProducer) Produce(_ context.Context, msgs ...kafka.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.messages = append(p.messages, msgs...)
+
+	return nil
+}
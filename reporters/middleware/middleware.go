@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+)
+
+// Middleware decorates a Reporter, deciding which statuses are forwarded to
+// it and how.
+type Middleware func(next health.Reporter) health.Reporter
+
+// Wrap decorates the given reporter with the given middlewares. The first
+// middleware is the outermost one, so it is the first to receive a status:
+//
+//	reporter := middleware.Wrap(hook,
+//		middleware.IncludeProbes("postgres", "redis"),
+//		middleware.OnChange(),
+//	)
+//
+// forwards to hook only the statuses where the postgres or redis probes
+// changed their state.
+func Wrap(r health.Reporter, mws ...Middleware) health.Reporter {
+	for i := len(mws) - 1; i >= 0; i-- {
+		r = mws[i](r)
+	}
+
+	return r
+}
+
+// OnChange forwards a status only when a probe transitions between passing and
+// failing since the last forwarded status. Probes seen for the first time are
+// only considered a transition if failing, so a healthy first status is not
+// forwarded. If the decorated reporter fails, the status is not considered
+// forwarded, so it is forwarded again on retry.
+func OnChange() Middleware {
+	return func(next health.Reporter) health.Reporter {
+		t := &tracker{}
+
+		return health.ReporterFunc(func(ctx context.Context, status health.Status) error {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			changes, state := t.Diff(status)
+			if len(changes) == 0 {
+				return nil
+			}
+
+			if err := next.Report(ctx, status); err != nil {
+				return err
+			}
+
+			t.Commit(state)
+
+			return nil
+		})
+	}
+}
+
+// RateLimit forwards at most one status per interval, dropping the others,
+// unless a probe transitions between passing and failing since the last
+// forwarded status, see OnChange, in which case it is always forwarded.
+func RateLimit(interval time.Duration) Middleware {
+	return func(next health.Reporter) health.Reporter {
+		t := &tracker{}
+
+		var last time.Time
+
+		return health.ReporterFunc(func(ctx context.Context, status health.Status) error {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			changes, state := t.Diff(status)
+			if len(changes) == 0 && time.Since(last) < interval {
+				return nil
+			}
+
+			if err := next.Report(ctx, status); err != nil {
+				return err
+			}
+
+			t.Commit(state)
+			last = time.Now()
+
+			return nil
+		})
+	}
+}
+
+// Filter forwards the statuses restricted to the probes for which keep returns
+// true, see health.Filter. Statuses without any of these probes are dropped.
+func Filter(keep func(probeName string) bool) Middleware {
+	return func(next health.Reporter) health.Reporter {
+		return health.ReporterFunc(func(ctx context.Context, status health.Status) error {
+			filtered := health.Filter(status, keep)
+			if len(filtered.Errors()) == 0 {
+				return nil
+			}
+
+			return next.Report(ctx, filtered)
+		})
+	}
+}
+
+// IncludeProbes forwards the statuses restricted to the given probes,
+// for example the probes of a group of dependencies.
+func IncludeProbes(probes ...string) Middleware {
+	set := toSet(probes)
+
+	return Filter(func(name string) bool {
+		_, ok := set[name]
+
+		return ok
+	})
+}
+
+// ExcludeProbes forwards the statuses without the given probes.
+func ExcludeProbes(probes ...string) Middleware {
+	set := toSet(probes)
+
+	return Filter(func(name string) bool {
+		_, ok := set[name]
+
+		return !ok
+	})
+}
+
+// Map forwards the statuses returned by fn, for example to rename probes or
+// to ignore known errors. If fn returns nil, the status is dropped.
+func Map(fn func(health.Status) health.Status) Middleware {
+	return func(next health.Reporter) health.Reporter {
+		return health.ReporterFunc(func(ctx context.Context, status health.Status) error {
+			mapped := fn(status)
+			if mapped == nil {
+				return nil
+			}
+
+			return next.Report(ctx, mapped)
+		})
+	}
+}
+
+// tracker guards a transition.Tracker shared by concurrent reports.
+type tracker struct {
+	transition.Tracker
+	mu sync.Mutex
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+
+	return set
+}
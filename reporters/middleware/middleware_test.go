@@ -0,0 +1,143 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnChange(t *testing.T) {
+	rec := &recorder{}
+	reporter := middleware.Wrap(rec, middleware.OnChange())
+
+	healthy := health.NewStatus().Append("db", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	// Neither a different error nor a new passing probe is a transition.
+	added := health.NewStatus().Append("db", errors.New("timeout")).Append("cache", nil)
+
+	for _, st := range []health.Status{healthy, healthy, unhealthy, unhealthy, added, added, healthy} {
+		require.NoError(t, reporter.Report(context.Background(), st))
+	}
+
+	assert.Equal(t, []string{"db=connection refused", "db=ok"}, rec.reports)
+}
+
+func TestOnChange_RetriesFailedReports(t *testing.T) {
+	rec := &recorder{err: errors.New("unavailable")}
+	reporter := middleware.Wrap(rec, middleware.OnChange())
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	require.Error(t, reporter.Report(context.Background(), unhealthy))
+
+	rec.err = nil
+
+	require.NoError(t, reporter.Report(context.Background(), unhealthy))
+	assert.Equal(t, []string{"db=connection refused"}, rec.reports)
+}
+
+func TestRateLimit(t *testing.T) {
+	rec := &recorder{}
+	reporter := middleware.Wrap(rec, middleware.RateLimit(50*time.Millisecond))
+
+	healthy := health.NewStatus().Append("db", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	require.NoError(t, reporter.Report(context.Background(), healthy))
+	require.NoError(t, reporter.Report(context.Background(), healthy))
+
+	// Transitions are forwarded within the interval.
+	require.NoError(t, reporter.Report(context.Background(), unhealthy))
+	require.NoError(t, reporter.Report(context.Background(), unhealthy))
+
+	time.Sleep(60 * time.Millisecond)
+
+	require.NoError(t, reporter.Report(context.Background(), unhealthy))
+
+	assert.Equal(t, []string{"db=ok", "db=connection refused", "db=connection refused"}, rec.reports)
+}
+
+func TestFilter(t *testing.T) {
+	status := health.NewStatus().
+		Append("db", errors.New("connection refused")).
+		Append("cache", nil).
+		Append("s3", errors.New("access denied"))
+
+	rec := &recorder{}
+	require.NoError(t, middleware.Wrap(rec, middleware.IncludeProbes("cache", "db")).Report(context.Background(), status))
+	require.NoError(t, middleware.Wrap(rec, middleware.ExcludeProbes("db", "s3")).Report(context.Background(), status))
+
+	// Statuses without any of the probes are dropped.
+	require.NoError(t, middleware.Wrap(rec, middleware.IncludeProbes("queue")).Report(context.Background(), status))
+
+	assert.Equal(t, []string{"cache=ok db=connection refused", "cache=ok"}, rec.reports)
+}
+
+func TestMap(t *testing.T) {
+	rec := &recorder{}
+	ignoreS3 := middleware.Map(func(st health.Status) health.Status {
+		out := health.NewStatus()
+		for name, err := range st.Errors() {
+			if name == "s3" {
+				err = nil
+			}
+
+			out = out.Append(name, err)
+		}
+
+		return out
+	})
+
+	dropHealthy := middleware.Map(func(st health.Status) health.Status {
+		if st.AsError() == nil {
+			return nil
+		}
+
+		return st
+	})
+
+	reporter := middleware.Wrap(rec, ignoreS3, dropHealthy)
+
+	require.NoError(t, reporter.Report(context.Background(), health.NewStatus().Append("s3", errors.New("access denied"))))
+	require.NoError(t, reporter.Report(context.Background(), health.NewStatus().Append("db", errors.New("timeout"))))
+
+	assert.Equal(t, []string{"db=timeout"}, rec.reports)
+}
+
+// recorder is a reporter recording the received statuses as
+// sorted "probe=result" strings.
+type recorder struct {
+	reports []string
+	err     error
+}
+
+func (r *recorder) Report(_ context.Context, status health.Status) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	parts := make([]string, 0)
+
+	for name, err := range status.Errors() {
+		result := "ok"
+		if err != nil {
+			result = err.Error()
+		}
+
+		parts = append(parts, name+"="+result)
+	}
+
+	sort.Strings(parts)
+
+	r.reports = append(r.reports, strings.Join(parts, " "))
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package otelmetrics
+
+import "go.opentelemetry.io/otel/metric"
+
+// Option is a functional option for the OpenTelemetry metrics reporter.
+type Option func(*options)
+
+type options struct {
+	provider metric.MeterProvider
+	groups   map[string]string
+}
+
+// WithMeterProvider sets the MeterProvider used to create the instruments.
+// If not provided, the global MeterProvider is used.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(o *options) {
+		o.provider = provider
+	}
+}
+
+// WithGroup assigns the given probes to a group. The group name is recorded
+// as the "group" attribute of every measurement of those probes. Probes not
+// assigned to any group are recorded without the attribute.
+func WithGroup(group string, probes ...string) Option {
+	return func(o *options) {
+		for i := range probes {
+			o.groups[probes[i]] = group
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package otelmetrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/botchris/go-health"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	meterName = "github.com/botchris/go-health/reporters/otelmetrics"
+
+	// ProbeAttribute is the attribute key holding the name of the probe.
+	ProbeAttribute = attribute.Key("probe")
+
+	// GroupAttribute is the attribute key holding the group of the probe.
+	GroupAttribute = attribute.Key("group")
+)
+
+type otelReporter struct {
+	opts     *options
+	health   metric.Int64Gauge
+	duration metric.Float64Histogram
+	overall  metric.Int64Gauge
+}
+
+// New creates a new reporter that records health status as OpenTelemetry
+// instruments:
+//
+//   - "health.probe.status": a gauge set to 1 when the probe passes, 0 otherwise.
+//   - "health.probe.duration": a histogram with the probe latency in seconds.
+//   - "health.status": a gauge set to 1 when all probes pass, 0 otherwise.
+//
+// Probe measurements carry the probe name and, if configured using WithGroup,
+// the group attributes.
+func New(o ...Option) (health.Reporter, error) {
+	opts := &options{
+		provider: otel.GetMeterProvider(),
+		groups:   make(map[string]string),
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	meter := opts.provider.Meter(meterName)
+
+	hGauge, err := meter.Int64Gauge(
+		"health.probe.status",
+		metric.WithDescription("Health of the probe, 1 if passing and 0 otherwise."),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("otelmetrics: creating probe status gauge failed: %w", err)
+	}
+
+	dHist, err := meter.Float64Histogram(
+		"health.probe.duration",
+		metric.WithDescription("Time taken by the probe to complete its check."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("otelmetrics: creating probe duration histogram failed: %w", err)
+	}
+
+	oGauge, err := meter.Int64Gauge(
+		"health.status",
+		metric.WithDescription("Overall health, 1 if all probes are passing and 0 otherwise."),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("otelmetrics: creating status gauge failed: %w", err)
+	}
+
+	return &otelReporter{
+		opts:     opts,
+		health:   hGauge,
+		duration: dHist,
+		overall:  oGauge,
+	}, nil
+}
+
+func (r *otelReporter) Report(ctx context.Context, status health.Status) error {
+	durations := health.Durations(status)
+
+	for probe, err := range status.Errors() {
+		attrs := metric.WithAttributes(r.attributes(probe)...)
+
+		r.health.Record(ctx, boolToInt(err == nil), attrs)
+
+		if d, ok := durations[probe]; ok {
+			r.duration.Record(ctx, d.Seconds(), attrs)
+		}
+	}
+
+	r.overall.Record(ctx, boolToInt(status.AsError() == nil))
+
+	return nil
+}
+
+func (r *otelReporter) attributes(probe string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{ProbeAttribute.String(probe)}
+
+	if group, ok := r.opts.groups[probe]; ok {
+		attrs = append(attrs, GroupAttribute.String(group))
+	}
+
+	return attrs
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
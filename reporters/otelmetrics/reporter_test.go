@@ -0,0 +1,72 @@
+package otelmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/otelmetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestOtelReporter_RecordsProbeHealthAndLatency(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	reporter, err := otelmetrics.New(
+		otelmetrics.WithMeterProvider(provider),
+		otelmetrics.WithGroup("storage", "db"),
+	)
+	require.NoError(t, err)
+
+	status := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	probeStatus, ok := metrics["health.probe.status"].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, probeStatus.DataPoints, 2)
+
+	for _, dp := range probeStatus.DataPoints {
+		probe, _ := dp.Attributes.Value(otelmetrics.ProbeAttribute)
+		group, hasGroup := dp.Attributes.Value(otelmetrics.GroupAttribute)
+
+		switch probe.AsString() {
+		case "db":
+			assert.EqualValues(t, 0, dp.Value)
+			assert.True(t, hasGroup)
+			assert.Equal(t, attribute.StringValue("storage"), group)
+		case "cache":
+			assert.EqualValues(t, 1, dp.Value)
+			assert.False(t, hasGroup)
+		default:
+			t.Fatalf("unexpected probe %q", probe.AsString())
+		}
+	}
+
+	duration, ok := metrics["health.probe.duration"].Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+	assert.Len(t, duration.DataPoints, 2)
+
+	overall, ok := metrics["health.status"].Data.(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, overall.DataPoints, 1)
+	assert.EqualValues(t, 0, overall.DataPoints[0].Value)
+}
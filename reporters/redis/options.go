@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"errors"
+	"time"
+)
+
+// Option is a functional option for the Redis reporter.
+type Option func(*options) error
+
+type options struct {
+	keyPrefix string
+	ttl       time.Duration
+	channel   string
+}
+
+// WithKeyPrefix sets the prefix of the hash key holding the status of the
+// instance. Defaults to "health:", so the status of the instance "api-1" is
+// stored in the "health:api-1" key.
+func WithKeyPrefix(prefix string) Option {
+	return func(o *options) error {
+		o.keyPrefix = prefix
+
+		return nil
+	}
+}
+
+// WithTTL sets the expiration of the hash key, refreshed on every report,
+// so instances which stop reporting disappear. It must be longer than the
+// period of the Checker, see health.WithPeriod. Defaults to 30 seconds.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) error {
+		if ttl < time.Millisecond {
+			return errors.New("ttl must be at least one millisecond")
+		}
+
+		o.ttl = ttl
+
+		return nil
+	}
+}
+
+// WithChannel sets the pub/sub channel transitions are published on.
+// Defaults to "health:transitions".
+func WithChannel(channel string) Option {
+	return func(o *options) error {
+		if channel == "" {
+			return errors.New("channel cannot be empty")
+		}
+
+		o.channel = channel
+
+		return nil
+	}
+}
@@ -0,0 +1,214 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/redis/go-redis/v9/maintnotifications"
+)
+
+// Fields of the hash holding the status of an instance.
+const (
+	// FieldStatus holds "pass" if every probe passes, "fail" otherwise.
+	FieldStatus = "status"
+
+	// FieldProbes holds a JSON object mapping each probe to "ok" or its error.
+	FieldProbes = "probes"
+
+	// FieldUpdated holds the time of the last report, in RFC 3339 format.
+	FieldUpdated = "updated"
+)
+
+// Transition is the message published every time a probe transitions
+// between passing and failing.
+type Transition struct {
+	// Instance is the name of the instance reporting the transition.
+	Instance string `json:"instance"`
+
+	// Time is the time the transition was detected.
+	Time time.Time `json:"time"`
+
+	// Healthy tells whether every probe of the instance is passing.
+	Healthy bool `json:"healthy"`
+
+	// Changed holds the probes whose state changed, sorted by name.
+	Changed []Probe `json:"changed"`
+}
+
+// Probe is the result of a single probe within a Transition.
+type Probe struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+type reporter struct {
+	client   *redis.Client
+	instance string
+	opts     *options
+
+	tracker transition.Tracker
+	closed  bool
+	mu      sync.Mutex
+}
+
+// New creates a new reporter which stores the status of the given instance
+// in a Redis hash, and publishes a Transition message every time a probe
+// transitions between passing and failing, so a central dashboard can
+// aggregate the health of many instances by scanning the hash keys and
+// subscribing to the channel. Probes seen for the first time are only
+// considered a transition if failing.
+//
+// The hash holds the FieldStatus, FieldProbes and FieldUpdated fields, and
+// expires unless refreshed by a report, see WithTTL. Once the given context
+// is canceled, the hash is deleted and the Redis client closed.
+func New(ctx context.Context, dsn, instance string, o ...Option) (health.Reporter, error) {
+	redisOptions, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis dsn: %w", err)
+	}
+
+	if redisOptions.MaintNotificationsConfig == nil {
+		redisOptions.MaintNotificationsConfig = &maintnotifications.Config{
+			Mode: maintnotifications.ModeDisabled,
+		}
+	}
+
+	opts := &options{
+		keyPrefix: "health:",
+		ttl:       30 * time.Second,
+		channel:   "health:transitions",
+	}
+
+	for i := range o {
+		if oErr := o[i](opts); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	r := &reporter{
+		client:   redis.NewClient(redisOptions),
+		instance: instance,
+		opts:     opts,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if cErr := r.close(); cErr != nil {
+			log.Printf("redis reporter: %v", cErr)
+		}
+	}()
+
+	return r, nil
+}
+
+func (r *reporter) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	now := time.Now()
+	errs := status.Errors()
+	probes := make(map[string]string, len(errs))
+
+	for name, err := range errs {
+		probes[name] = "ok"
+		if err != nil {
+			probes[name] = err.Error()
+		}
+	}
+
+	encoded, err := json.Marshal(probes)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("redis reporter: encoding probes failed: %w", err))
+	}
+
+	result := "pass"
+	if status.AsError() != nil {
+		result = "fail"
+	}
+
+	key := r.opts.keyPrefix + r.instance
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, FieldStatus, result, FieldProbes, string(encoded), FieldUpdated, now.UTC().Format(time.RFC3339Nano))
+	pipe.PExpire(ctx, key, r.opts.ttl)
+
+	t, state := r.transition(status, now)
+	if t != nil {
+		msg, mErr := json.Marshal(t)
+		if mErr != nil {
+			return backoff.Permanent(fmt.Errorf("redis reporter: encoding transition failed: %w", mErr))
+		}
+
+		pipe.Publish(ctx, r.opts.channel, msg)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis reporter: writing status failed: %w", err)
+	}
+
+	r.tracker.Commit(state)
+
+	return nil
+}
+
+// transition returns the message to be published for the given status, or
+// nil if no probe changed its state since the last published transition.
+// It also returns the state to be committed once the message is published.
+func (r *reporter) transition(status health.Status, now time.Time) (*Transition, transition.State) {
+	changes, state := r.tracker.Diff(status)
+	if len(changes) == 0 {
+		return nil, state
+	}
+
+	t := &Transition{
+		Instance: r.instance,
+		Time:     now,
+		Healthy:  status.AsError() == nil,
+	}
+
+	for _, c := range changes {
+		p := Probe{Name: c.Probe, Healthy: !c.Failing()}
+		if c.Failing() {
+			p.Error = c.Err.Error()
+		}
+
+		t.Changed = append(t.Changed, p)
+	}
+
+	return t, state
+}
+
+// close deletes the hash of the instance and closes the client.
+func (r *reporter) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	delErr := r.client.Del(ctx, r.opts.keyPrefix+r.instance).Err()
+	if err := r.client.Close(); err != nil {
+		return fmt.Errorf("closing client failed: %w", err)
+	}
+
+	if delErr != nil {
+		return fmt.Errorf("deleting status failed: %w", delErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/redis"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_StoresStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := miniredis.RunT(t)
+
+	reporter, err := redis.New(ctx, fmt.Sprintf("redis://%s", srv.Addr()), "api-1", redis.WithTTL(time.Minute))
+	require.NoError(t, err)
+
+	status := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+	require.NoError(t, reporter.Report(ctx, status))
+
+	assert.Equal(t, "fail", srv.HGet("health:api-1", redis.FieldStatus))
+	assert.JSONEq(t, `{"db":"connection refused","cache":"ok"}`, srv.HGet("health:api-1", redis.FieldProbes))
+	assert.NotEmpty(t, srv.HGet("health:api-1", redis.FieldUpdated))
+	assert.Equal(t, time.Minute, srv.TTL("health:api-1"))
+
+	// Instances which stop reporting disappear.
+	srv.FastForward(time.Minute)
+	assert.False(t, srv.Exists("health:api-1"))
+
+	require.NoError(t, reporter.Report(ctx, status))
+	assert.True(t, srv.Exists("health:api-1"))
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		return !srv.Exists("health:api-1")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReporter_PublishesTransitions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := miniredis.RunT(t)
+
+	client := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	defer func() { _ = client.Close() }()
+
+	sub := client.Subscribe(ctx, "transitions")
+	defer func() { _ = sub.Close() }()
+
+	_, err := sub.Receive(ctx)
+	require.NoError(t, err)
+
+	reporter, err := redis.New(ctx, fmt.Sprintf("redis://%s", srv.Addr()), "api-1",
+		redis.WithKeyPrefix("status:"),
+		redis.WithChannel("transitions"),
+	)
+	require.NoError(t, err)
+
+	healthy := health.NewStatus().Append("db", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused"))
+
+	// Passing probes seen for the first time are not a transition.
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	assert.Equal(t, "pass", srv.HGet("status:api-1", redis.FieldStatus))
+
+	receive := func() redis.Transition {
+		msgCtx, msgCancel := context.WithTimeout(ctx, time.Second)
+		defer msgCancel()
+
+		msg, rErr := sub.ReceiveMessage(msgCtx)
+		require.NoError(t, rErr)
+
+		var tr redis.Transition
+
+		require.NoError(t, json.Unmarshal([]byte(msg.Payload), &tr))
+
+		return tr
+	}
+
+	first, second := receive(), receive()
+
+	assert.Equal(t, "api-1", first.Instance)
+	assert.False(t, first.Healthy)
+	assert.Equal(t, []redis.Probe{{Name: "db", Error: "connection refused"}}, first.Changed)
+	assert.True(t, second.Healthy)
+	assert.Equal(t, []redis.Probe{{Name: "db", Healthy: true}}, second.Changed)
+}
+
+func TestReporter_ServerDown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reporter, err := redis.New(ctx, "redis://localhost:63999", "api-1")
+	require.NoError(t, err)
+	require.Error(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+}
+
+func TestNew_InvalidDSN(t *testing.T) {
+	_, err := redis.New(context.Background(), "invalid-dsn", "api-1")
+	require.Error(t, err)
+}
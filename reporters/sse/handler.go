@@ -0,0 +1,326 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// Handler is an http.Handler which streams health Status updates to its
+// clients as Server-Sent Events.
+type Handler struct {
+	opts *options
+
+	history     []event
+	last        map[string]string
+	nextID      uint64
+	subscribers map[chan event]struct{}
+	closed      bool
+	mu          sync.Mutex
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// event is a single Server-Sent Event.
+type event struct {
+	id   uint64
+	data []byte
+}
+
+// eventData is the JSON payload of each event.
+type eventData struct {
+	Healthy  bool                 `json:"healthy"`
+	Time     time.Time            `json:"time"`
+	Duration float64              `json:"durationMs"`
+	Probes   map[string]probeData `json:"probes"`
+}
+
+// probeData is the result of a single probe within an event.
+type probeData struct {
+	Healthy  bool    `json:"healthy"`
+	Duration float64 `json:"durationMs"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// New creates a new Handler streaming the Status updates received from the
+// given channel, typically obtained from Checker.Watch:
+//
+//	mux.Handle("/healthz/stream", sse.New(ctx, checker.Watch()))
+//
+// Each Status is sent as a "status" event with a JSON payload and an
+// increasing id. A Status whose probe results are the same as the ones of
+// the last event is not sent, idle connections being kept alive by the
+// heartbeat instead. Clients reconnecting with the Last-Event-ID header are
+// replayed the events they missed, if still available, or the latest one
+// otherwise. Newly connected clients receive the latest event right away.
+//
+// Streams are closed when the given context is canceled or the channel is
+// closed.
+func New(ctx context.Context, statuses <-chan health.Status, o ...Option) *Handler {
+	opts := &options{
+		heartbeat:   15 * time.Second,
+		retry:       3 * time.Second,
+		historySize: 100,
+		bufferSize:  10,
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	h := &Handler{
+		opts:        opts,
+		nextID:      1,
+		subscribers: make(map[chan event]struct{}),
+	}
+
+	go h.consume(ctx, statuses)
+
+	return h
+}
+
+// ServeHTTP streams events to the client until the request is canceled
+// or the Handler is closed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+
+		return
+	}
+
+	ch, backlog := h.subscribe(req.Header.Get("Last-Event-ID"))
+	defer h.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if h.opts.retry > 0 {
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", h.opts.retry.Milliseconds()); err != nil {
+			return
+		}
+	}
+
+	for i := range backlog {
+		if err := writeEvent(w, backlog[i]); err != nil {
+			return
+		}
+	}
+
+	flusher.Flush()
+
+	var heartbeat <-chan time.Time
+
+	if h.opts.heartbeat > 0 {
+		ticker := time.NewTicker(h.opts.heartbeat)
+		defer ticker.Stop()
+
+		heartbeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-heartbeat:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case ev, open := <-ch:
+			if !open {
+				return
+			}
+
+			if err := writeEvent(w, ev); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func (h *Handler) consume(ctx context.Context, statuses <-chan health.Status) {
+	defer h.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case st, ok := <-statuses:
+			if !ok {
+				return
+			}
+
+			h.publish(st)
+		}
+	}
+}
+
+// publish records the given status in the history and sends it to every
+// subscriber, unless its probe results did not change since the last
+// published one. Subscribers whose buffer is full are disconnected.
+func (h *Handler) publish(st health.Status) {
+	results := probeResults(st)
+
+	h.mu.Lock()
+	unchanged := h.last != nil && maps.Equal(h.last, results)
+	h.mu.Unlock()
+
+	if unchanged {
+		return
+	}
+
+	data, err := json.Marshal(newEventData(st))
+	if err != nil {
+		log.Printf("sse handler: failed to marshal status: %v", err)
+
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ev := event{id: h.nextID, data: data}
+	h.nextID++
+	h.last = results
+
+	h.history = append(h.history, ev)
+	if len(h.history) > h.opts.historySize {
+		h.history = h.history[len(h.history)-h.opts.historySize:]
+	}
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe registers a new subscriber and returns the events to be sent
+// before any new one, based on the given Last-Event-ID.
+func (h *Handler) subscribe(lastEventID string) (chan event, []event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan event, h.opts.bufferSize)
+
+	if h.closed {
+		close(ch)
+	} else {
+		h.subscribers[ch] = struct{}{}
+	}
+
+	if len(h.history) == 0 {
+		return ch, nil
+	}
+
+	latest := []event{h.history[len(h.history)-1]}
+
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return ch, latest
+	}
+
+	// The client may have missed events no longer in the history, or come
+	// from another instance, in which case only the latest event is sent.
+	if lastID+1 < h.history[0].id || lastID >= h.nextID {
+		return ch, latest
+	}
+
+	backlog := make([]event, 0, len(h.history))
+
+	for i := range h.history {
+		if h.history[i].id > lastID {
+			backlog = append(backlog, h.history[i])
+		}
+	}
+
+	return ch, backlog
+}
+
+func (h *Handler) unsubscribe(ch chan event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (h *Handler) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		close(ch)
+	}
+
+	h.subscribers = make(map[chan event]struct{})
+	h.closed = true
+}
+
+func writeEvent(w http.ResponseWriter, ev event) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", ev.id, ev.data)
+
+	return err
+}
+
+func newEventData(st health.Status) eventData {
+	durations := health.Durations(st)
+	out := eventData{
+		Healthy:  st.AsError() == nil,
+		Time:     time.Now().UTC(),
+		Duration: toMilliseconds(st.Duration()),
+		Probes:   make(map[string]probeData),
+	}
+
+	for name, err := range st.Errors() {
+		p := probeData{
+			Healthy:  err == nil,
+			Duration: toMilliseconds(durations[name]),
+		}
+
+		if err != nil {
+			p.Error = err.Error()
+		}
+
+		out.Probes[name] = p
+	}
+
+	return out
+}
+
+// probeResults returns the result of each probe of the given status,
+// either an empty string on success or the error message.
+func probeResults(st health.Status) map[string]string {
+	out := make(map[string]string, len(st.Errors()))
+
+	for name, err := range st.Errors() {
+		out[name] = ""
+
+		if err != nil {
+			out[name] = err.Error()
+		}
+	}
+
+	return out
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
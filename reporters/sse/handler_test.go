@@ -0,0 +1,182 @@
+package sse_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/sse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_StreamsStatuses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	srv := httptest.NewServer(sse.New(ctx, statuses, sse.WithHeartbeat(50*time.Millisecond)))
+
+	defer srv.Close()
+
+	res, lines := connect(ctx, t, srv.URL, "")
+	defer func() { _ = res.Body.Close() }()
+
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal(t, "retry: 3000", <-lines)
+	assert.Empty(t, <-lines)
+	assert.Equal(t, ": heartbeat", <-lines)
+	assert.Empty(t, <-lines)
+
+	statuses <- health.NewStatus().Append("db", errors.New("connection refused"))
+
+	ev := readEvent(t, lines)
+	assert.Equal(t, "id: 1", ev[0])
+	assert.Equal(t, "event: status", ev[1])
+	assert.Contains(t, ev[2], `"healthy":false`)
+	assert.Contains(t, ev[2], `"error":"connection refused"`)
+}
+
+func TestHandler_ReplaysMissedEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	srv := httptest.NewServer(sse.New(ctx, statuses, sse.WithRetry(0), sse.WithHistorySize(2)))
+
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		statuses <- health.NewStatus().Append("db", fmt.Errorf("attempt %d failed", i))
+	}
+
+	t.Run("new clients receive the latest event", func(t *testing.T) {
+		res, lines := connect(ctx, t, srv.URL, "")
+		defer func() { _ = res.Body.Close() }()
+
+		assert.Equal(t, "id: 3", readEvent(t, lines)[0])
+	})
+
+	t.Run("reconnecting clients receive missed events", func(t *testing.T) {
+		res, lines := connect(ctx, t, srv.URL, "2")
+		defer func() { _ = res.Body.Close() }()
+
+		assert.Equal(t, "id: 3", readEvent(t, lines)[0])
+
+		statuses <- health.NewStatus().Append("db", nil)
+
+		assert.Equal(t, "id: 4", readEvent(t, lines)[0])
+	})
+
+	t.Run("missed events no longer available", func(t *testing.T) {
+		res, lines := connect(ctx, t, srv.URL, "1")
+		defer func() { _ = res.Body.Close() }()
+
+		assert.Equal(t, "id: 4", readEvent(t, lines)[0])
+	})
+}
+
+func TestHandler_SkipsUnchangedStatuses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	srv := httptest.NewServer(sse.New(ctx, statuses, sse.WithRetry(0)))
+
+	defer srv.Close()
+
+	res, lines := connect(ctx, t, srv.URL, "")
+	defer func() { _ = res.Body.Close() }()
+
+	statuses <- health.NewStatus().Append("db", nil)
+	statuses <- health.NewStatus().Append("db", nil)
+	statuses <- health.NewStatus().Append("db", errors.New("connection refused"))
+
+	assert.Equal(t, "id: 1", readEvent(t, lines)[0])
+
+	ev := readEvent(t, lines)
+	assert.Equal(t, "id: 2", ev[0])
+	assert.Contains(t, ev[2], `"error":"connection refused"`)
+}
+
+func TestHandler_ClosedWithChannel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	statuses := make(chan health.Status)
+	srv := httptest.NewServer(sse.New(ctx, statuses, sse.WithRetry(0)))
+
+	defer srv.Close()
+
+	res, lines := connect(ctx, t, srv.URL, "")
+	defer func() { _ = res.Body.Close() }()
+
+	close(statuses)
+
+	select {
+	case _, ok := <-lines:
+		assert.False(t, ok, "stream should be closed")
+	case <-ctx.Done():
+		t.Fatal("stream was not closed")
+	}
+}
+
+func connect(ctx context.Context, t *testing.T, url, lastEventID string) (*http.Response, <-chan string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	lines := make(chan string)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	return res, lines
+}
+
+// readEvent returns the lines of the next event, skipping comments.
+func readEvent(t *testing.T, lines <-chan string) []string {
+	t.Helper()
+
+	var ev []string
+
+	for line := range lines {
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		if line == "" && len(ev) > 0 {
+			return ev
+		}
+
+		if line != "" {
+			ev = append(ev, line)
+		}
+	}
+
+	t.Fatal("stream closed before receiving an event")
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package sse
+
+import "time"
+
+// Option is a functional option for the Server-Sent Events handler.
+type Option func(*options)
+
+type options struct {
+	heartbeat   time.Duration
+	retry       time.Duration
+	historySize int
+	bufferSize  int
+}
+
+// WithHeartbeat sets the interval at which a comment is sent to connected
+// clients when there are no events, which keeps idle connections open
+// through proxies. Zero or negative values disable heartbeats. Defaults
+// to 15 seconds.
+func WithHeartbeat(d time.Duration) Option {
+	return func(o *options) {
+		o.heartbeat = d
+	}
+}
+
+// WithRetry sets the reconnection delay advertised to clients, which
+// browsers use to reconnect when the stream is interrupted. Zero or
+// negative values let clients use their default. Defaults to 3 seconds.
+func WithRetry(d time.Duration) Option {
+	return func(o *options) {
+		o.retry = d
+	}
+}
+
+// WithHistorySize sets how many past events are kept to be replayed to
+// clients reconnecting with the Last-Event-ID header. The value must be
+// at least 1. If a value less than 1 is provided, it defaults to 1.
+// Defaults to 100.
+func WithHistorySize(size int) Option {
+	return func(o *options) {
+		if size < 1 {
+			size = 1
+		}
+
+		o.historySize = size
+	}
+}
+
+// WithBufferSize sets how many events can be queued for each connected
+// client. Clients which cannot keep up are disconnected, so they can
+// reconnect and resume from their last received event. The value must be
+// at least 1. If a value less than 1 is provided, it defaults to 1.
+// Defaults to 10.
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		if size < 1 {
+			size = 1
+		}
+
+		o.bufferSize = size
+	}
+}
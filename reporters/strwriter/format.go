@@ -0,0 +1,185 @@
+package strwriter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// jsonProbe is the result of a probe in the detailed JSON format.
+type jsonProbe struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// jsonReport is the detailed JSON format, used when timestamps
+// or durations are enabled.
+type jsonReport struct {
+	Time   string               `json:"time,omitempty"`
+	Probes map[string]jsonProbe `json:"probes"`
+}
+
+func (i *writer) statusToLogLine(r report) string {
+	var (
+		jsonOut []byte
+		jErr    error
+	)
+
+	if i.opts.timestamps || i.opts.durations {
+		out := jsonReport{Probes: make(map[string]jsonProbe, len(r.probes))}
+		if i.opts.timestamps {
+			out.Time = formatTime(r.time)
+		}
+
+		for _, p := range r.probes {
+			jp := jsonProbe{Status: result(p)}
+			if i.opts.durations {
+				jp.Duration = formatDuration(p.duration)
+			}
+
+			out.Probes[p.name] = jp
+		}
+
+		jsonOut, jErr = json.Marshal(out)
+	} else {
+		out := make(map[string]string, len(r.probes))
+		for _, p := range r.probes {
+			out[p.name] = result(p)
+		}
+
+		jsonOut, jErr = json.Marshal(out)
+	}
+
+	if jErr == nil {
+		return string(jsonOut) + "\n"
+	}
+
+	return fmt.Sprintf("failed to marshal health status to JSON: %v; raw status: %v\n", jErr, r.probes)
+}
+
+func (i *writer) logfmt(r report) string {
+	sb := strings.Builder{}
+
+	for _, p := range r.probes {
+		if i.opts.timestamps {
+			sb.WriteString("time=" + formatTime(r.time) + " ")
+		}
+
+		sb.WriteString("probe=" + logfmtValue(p.name))
+
+		if p.err == nil {
+			sb.WriteString(" status=ok")
+		} else {
+			sb.WriteString(" status=failed error=" + logfmtValue(p.err.Error()))
+		}
+
+		if i.opts.durations {
+			sb.WriteString(" duration=" + formatDuration(p.duration))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (i *writer) table(r report) string {
+	sb := strings.Builder{}
+
+	if i.opts.timestamps {
+		sb.WriteString(formatTime(r.time) + "\n")
+	}
+
+	tb := strings.Builder{}
+	tw := tabwriter.NewWriter(&tb, 0, 0, 2, ' ', 0)
+	header := "PROBE\tSTATUS"
+
+	if i.opts.durations {
+		header += "\tDURATION"
+	}
+
+	_, _ = fmt.Fprintln(tw, header+"\tERROR")
+
+	for _, p := range r.probes {
+		status, errMsg := "ok", ""
+		if p.err != nil {
+			status, errMsg = "failed", p.err.Error()
+		}
+
+		row := p.name + "\t" + status
+		if i.opts.durations {
+			row += "\t" + formatDuration(p.duration)
+		}
+
+		_, _ = fmt.Fprintln(tw, row+"\t"+strings.ReplaceAll(errMsg, "\n", " "))
+	}
+
+	_ = tw.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(tb.String(), "\n"), "\n")
+
+	// The header is ASCII, so its byte offset is also the rune offset
+	// of the status column in every row.
+	offset := strings.Index(lines[0], "STATUS")
+
+	for n, line := range lines {
+		line = strings.TrimRight(line, " ")
+
+		// Colors are applied once the table is aligned, as tabwriter would
+		// count the escape codes as part of the width of the cells.
+		if n > 0 && i.color {
+			runes := []rune(line)
+			color, width := ansiGreen, len("ok")
+
+			if r.probes[n-1].err != nil {
+				color, width = ansiRed, len("failed")
+			}
+
+			line = string(runes[:offset]) + color + string(runes[offset:offset+width]) + ansiReset + string(runes[offset+width:])
+		}
+
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String()
+}
+
+// result returns "ok" or the error of the given probe.
+func result(p probe) string {
+	if p.err == nil {
+		return "ok"
+	}
+
+	return p.err.Error()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+// logfmtValue quotes the given value if it contains spaces, quotes,
+// equal signs or control characters.
+func logfmtValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	if strings.ContainsFunc(s, func(r rune) bool { return r <= ' ' || r == '=' || r == '"' }) {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
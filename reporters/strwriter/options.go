@@ -0,0 +1,72 @@
+package strwriter
+
+// Format is the format of the lines written by the reporter.
+type Format int
+
+const (
+	// FormatJSON writes a JSON object per report, mapping each probe to "ok"
+	// or its error. If timestamps or durations are enabled, the object holds
+	// a "time" key and a "probes" key mapping each probe to an object with
+	// its "status" and "duration".
+	FormatJSON Format = iota
+
+	// FormatLogfmt writes a logfmt line per probe, for example:
+	//
+	//	probe=db status=failed error="connection refused"
+	FormatLogfmt
+
+	// FormatTable writes a human-readable table per report, with a row per
+	// probe. Statuses are colored using ANSI escape codes when writing to
+	// a terminal, see WithColor.
+	FormatTable
+)
+
+// Option is a functional option for the string writer reporter.
+type Option func(*options)
+
+type options struct {
+	format          Format
+	timestamps      bool
+	durations       bool
+	transitionsOnly bool
+	color           *bool
+}
+
+// WithFormat sets the format of the written lines. Defaults to FormatJSON.
+func WithFormat(f Format) Option {
+	return func(o *options) {
+		o.format = f
+	}
+}
+
+// WithTimestamps includes the time of each report in the output.
+func WithTimestamps() Option {
+	return func(o *options) {
+		o.timestamps = true
+	}
+}
+
+// WithDurations includes the time each probe took to complete in the output.
+func WithDurations() Option {
+	return func(o *options) {
+		o.durations = true
+	}
+}
+
+// WithTransitionsOnly only writes a report when a probe transitions between
+// passing and failing, instead of on every report. Probes seen for the first
+// time are only considered a transition if failing.
+func WithTransitionsOnly() Option {
+	return func(o *options) {
+		o.transitionsOnly = true
+	}
+}
+
+// WithColor forces enabling or disabling ANSI colors in the FormatTable
+// format. By default, colors are enabled when writing to a terminal and
+// the NO_COLOR environment variable is not set.
+func WithColor(enabled bool) Option {
+	return func(o *options) {
+		o.color = &enabled
+	}
+}
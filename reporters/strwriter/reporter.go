@@ -2,46 +2,114 @@ package strwriter
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
 )
 
 type writer struct {
-	f io.StringWriter
+	f     io.StringWriter
+	opts  *options
+	color bool
+
+	tracker transition.Tracker
+	mu      sync.Mutex
 }
 
 // New creates a new string writer reporter which writes health
 // status to the provided io.StringWriter. For example, os.Stdout can be used
 // to print health status to the console.
-func New(f io.StringWriter) health.Reporter {
-	return &writer{f: f}
+//
+// By default, a JSON object mapping each probe to "ok" or its error is
+// written on every report, see WithFormat for other formats.
+func New(f io.StringWriter, o ...Option) health.Reporter {
+	opts := &options{format: FormatJSON}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	color := isTerminal(f) && os.Getenv("NO_COLOR") == ""
+	if opts.color != nil {
+		color = *opts.color
+	}
+
+	return &writer{f: f, opts: opts, color: color}
+}
+
+func (i *writer) Report(_ context.Context, status health.Status) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	changes, state := i.tracker.Diff(status)
+	if i.opts.transitionsOnly && len(changes) == 0 {
+		return nil
+	}
+
+	if _, err := i.f.WriteString(i.render(newReport(status))); err != nil {
+		return err
+	}
+
+	i.tracker.Commit(state)
+
+	return nil
 }
 
-func (i writer) Report(_ context.Context, status health.Status) error {
-	_, err := i.f.WriteString(i.statusToLogLine(status))
+func (i *writer) render(r report) string {
+	switch i.opts.format {
+	case FormatLogfmt:
+		return i.logfmt(r)
+	case FormatTable:
+		return i.table(r)
+	default:
+		return i.statusToLogLine(r)
+	}
+}
 
-	return err
+// report is the status being written, with probes sorted by name.
+type report struct {
+	time   time.Time
+	probes []probe
 }
 
-func (i writer) statusToLogLine(status health.Status) string {
-	out := make(map[string]string)
+type probe struct {
+	name     string
+	err      error
+	duration time.Duration
+}
 
-	for probe, err := range status.Errors() {
-		right := "ok"
-		if err != nil {
-			right = err.Error()
-		}
+func newReport(status health.Status) report {
+	errs := status.Errors()
+	durations := health.Durations(status)
+	r := report{time: time.Now()}
+
+	for name, err := range errs {
+		r.probes = append(r.probes, probe{name: name, err: err, duration: durations[name]})
+	}
+
+	sort.Slice(r.probes, func(a, b int) bool {
+		return r.probes[a].name < r.probes[b].name
+	})
+
+	return r
+}
 
-		out[probe] = right
+// isTerminal tells whether the given writer is a terminal.
+func isTerminal(f io.StringWriter) bool {
+	file, ok := f.(*os.File)
+	if !ok {
+		return false
 	}
 
-	jsonOut, jErr := json.Marshal(out)
-	if jErr == nil {
-		return string(jsonOut) + "\n"
+	info, err := file.Stat()
+	if err != nil {
+		return false
 	}
 
-	return fmt.Sprintf("failed to marshal health status to JSON: %v; raw status: %v", jErr, status.Errors())
+	return info.Mode()&os.ModeCharDevice != 0
 }
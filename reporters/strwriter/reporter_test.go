@@ -3,6 +3,7 @@ package strwriter_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/botchris/go-health"
 	"github.com/botchris/go-health/reporters/strwriter"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReport_WritesStatusToFile(t *testing.T) {
@@ -63,3 +65,77 @@ type fakeFile struct {
 func (f *fakeFile) WriteString(s string) (int, error) {
 	return f.buf.WriteString(s)
 }
+
+func TestReport_JSONDetails(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporter := strwriter.New(&fakeFile{buf: &buf}, strwriter.WithTimestamps(), strwriter.WithDurations())
+	status := health.NewStatus().Append("db", errors.New("connection failed")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(context.Background(), status))
+
+	out := struct {
+		Time   time.Time `json:"time"`
+		Probes map[string]struct {
+			Status   string `json:"status"`
+			Duration string `json:"duration"`
+		} `json:"probes"`
+	}{}
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.False(t, out.Time.IsZero())
+	assert.Equal(t, "connection failed", out.Probes["db"].Status)
+	assert.Equal(t, "ok", out.Probes["cache"].Status)
+	assert.NotEmpty(t, out.Probes["db"].Duration)
+}
+
+func TestReport_Logfmt(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporter := strwriter.New(&fakeFile{buf: &buf}, strwriter.WithFormat(strwriter.FormatLogfmt))
+	status := health.NewStatus().Append("db", errors.New("connection failed")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(context.Background(), status))
+	assert.Equal(t, "probe=cache status=ok\nprobe=db status=failed error=\"connection failed\"\n", buf.String())
+}
+
+func TestReport_Table(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporter := strwriter.New(&fakeFile{buf: &buf}, strwriter.WithFormat(strwriter.FormatTable))
+	status := health.NewStatus().Append("database", errors.New("connection failed")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(context.Background(), status))
+
+	want := "" +
+		"PROBE     STATUS  ERROR\n" +
+		"cache     ok\n" +
+		"database  failed  connection failed\n"
+	assert.Equal(t, want, buf.String())
+
+	buf.Reset()
+
+	colored := strwriter.New(&fakeFile{buf: &buf}, strwriter.WithFormat(strwriter.FormatTable), strwriter.WithColor(true))
+	require.NoError(t, colored.Report(context.Background(), status))
+
+	want = "" +
+		"PROBE     STATUS  ERROR\n" +
+		"cache     \x1b[32mok\x1b[0m\n" +
+		"database  \x1b[31mfailed\x1b[0m  connection failed\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestReport_TransitionsOnly(t *testing.T) {
+	var buf bytes.Buffer
+
+	reporter := strwriter.New(&fakeFile{buf: &buf}, strwriter.WithTransitionsOnly())
+	healthy := health.NewStatus().Append("db", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection failed"))
+
+	for _, status := range []health.Status{healthy, healthy, unhealthy, unhealthy, healthy} {
+		require.NoError(t, reporter.Report(context.Background(), status))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{`{"db":"connection failed"}`, `{"db":"ok"}`}, lines)
+}
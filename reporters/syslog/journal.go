@@ -0,0 +1,80 @@
+package syslog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/botchris/go-health"
+)
+
+// JournalSocket is the default path of the journald native protocol socket.
+const JournalSocket = "/run/systemd/journal/socket"
+
+// journalWriter writes entries to journald using its native protocol.
+type journalWriter struct {
+	opts *options
+}
+
+// NewJournal creates a new reporter which logs probe transitions to the
+// systemd journal using its native protocol. Besides MESSAGE, PRIORITY and
+// SYSLOG_IDENTIFIER, every entry includes the PROBE, STATE and ERROR fields,
+// so they can be queried with journalctl, for example:
+//
+//	journalctl PROBE=db STATE=unhealthy
+//
+// Probes which fail are logged with the err priority, and probes which
+// recover with the notice priority.
+func NewJournal(o ...Option) health.Reporter {
+	return &reporter{writer: &journalWriter{opts: newOptions(o)}}
+}
+
+func (w *journalWriter) write(entries []entry) (int, error) {
+	addr := &net.UnixAddr{Name: w.opts.socket, Net: "unixgram"}
+
+	conn, err := net.DialUnix(addr.Net, nil, addr)
+	if err != nil {
+		return 0, fmt.Errorf("journal: connecting to %s failed: %w", w.opts.socket, err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	for i, e := range entries {
+		buf := bytes.Buffer{}
+
+		appendField(&buf, "MESSAGE", e.message())
+		appendField(&buf, "PRIORITY", strconv.Itoa(e.severity()))
+		appendField(&buf, "SYSLOG_FACILITY", strconv.Itoa(int(w.opts.facility)))
+		appendField(&buf, "SYSLOG_IDENTIFIER", w.opts.appName)
+		appendField(&buf, "PROBE", e.probe)
+		appendField(&buf, "STATE", e.state())
+
+		if e.err != "" {
+			appendField(&buf, "ERROR", e.err)
+		}
+
+		if _, err := conn.Write(buf.Bytes()); err != nil {
+			return i, fmt.Errorf("journal: writing entry failed: %w", err)
+		}
+	}
+
+	return len(entries), nil
+}
+
+// appendField appends a field to the given buffer using the native protocol
+// serialization: values holding new lines are prefixed by their length as a
+// little-endian 64-bit integer.
+func appendField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key + "=" + value + "\n")
+
+		return
+	}
+
+	buf.WriteString(key + "\n")
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value + "\n")
+}
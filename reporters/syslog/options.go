@@ -0,0 +1,61 @@
+package syslog
+
+// Facility is the syslog facility of the messages, as defined by RFC 5424.
+type Facility int
+
+// Facilities commonly used by applications.
+const (
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+	FacilityLocal0 Facility = 16
+	FacilityLocal1 Facility = 17
+	FacilityLocal2 Facility = 18
+	FacilityLocal3 Facility = 19
+	FacilityLocal4 Facility = 20
+	FacilityLocal5 Facility = 21
+	FacilityLocal6 Facility = 22
+	FacilityLocal7 Facility = 23
+)
+
+// Option is a functional option for the syslog and journal reporters.
+type Option func(*options)
+
+type options struct {
+	appName  string
+	hostname string
+	facility Facility
+	socket   string
+}
+
+// WithAppName sets the APP-NAME of syslog messages, or the
+// SYSLOG_IDENTIFIER field of journal entries. Defaults to the name
+// of the running executable.
+func WithAppName(name string) Option {
+	return func(o *options) {
+		o.appName = name
+	}
+}
+
+// WithHostname sets the HOSTNAME of syslog messages. Defaults to the
+// hostname reported by the kernel. Ignored by the journal reporter,
+// as journald records the hostname itself.
+func WithHostname(hostname string) Option {
+	return func(o *options) {
+		o.hostname = hostname
+	}
+}
+
+// WithFacility sets the facility of the messages. Defaults to FacilityDaemon.
+func WithFacility(f Facility) Option {
+	return func(o *options) {
+		o.facility = f
+	}
+}
+
+// WithSocket sets the path of the journald native protocol socket.
+// Defaults to JournalSocket. Ignored by the syslog reporter.
+func WithSocket(path string) Option {
+	return func(o *options) {
+		o.socket = path
+	}
+}
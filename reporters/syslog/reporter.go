@@ -0,0 +1,120 @@
+package syslog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/transition"
+)
+
+// Severities used for the messages, as defined by RFC 5424.
+const (
+	severityErr    = 3
+	severityNotice = 5
+)
+
+// States reported in the STATE field of the messages.
+const (
+	stateHealthy   = "healthy"
+	stateUnhealthy = "unhealthy"
+)
+
+// entry is a single probe transition to be logged.
+type entry struct {
+	probe   string
+	healthy bool
+	err     string
+}
+
+// severity returns err for probes becoming unhealthy, notice for recoveries.
+func (e entry) severity() int {
+	if e.healthy {
+		return severityNotice
+	}
+
+	return severityErr
+}
+
+func (e entry) state() string {
+	if e.healthy {
+		return stateHealthy
+	}
+
+	return stateUnhealthy
+}
+
+func (e entry) message() string {
+	if e.healthy {
+		return "probe " + e.probe + " recovered"
+	}
+
+	return "probe " + e.probe + " failed: " + e.err
+}
+
+// writer delivers log entries to a log daemon.
+type writer interface {
+	// write delivers the given entries, in order. It returns the number of
+	// entries delivered, which is less than len(entries) on error.
+	write(entries []entry) (int, error)
+}
+
+type reporter struct {
+	writer writer
+
+	tracker transition.Tracker
+	mu      sync.Mutex
+}
+
+func newOptions(o []Option) *options {
+	opts := &options{
+		appName:  filepath.Base(os.Args[0]),
+		facility: FacilityDaemon,
+		socket:   JournalSocket,
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		opts.hostname = hostname
+	}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return opts
+}
+
+// Report logs an entry for every probe which transitioned between passing
+// and failing since the last report. Probes seen for the first time are only
+// logged if failing. If an entry cannot be written, the entries written
+// before it are not written again when the Checker retries.
+func (r *reporter) Report(_ context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes, state := r.tracker.Diff(status)
+	entries := make([]entry, 0, len(changes))
+
+	for _, c := range changes {
+		e := entry{probe: c.Probe, healthy: !c.Failing()}
+		if c.Failing() {
+			e.err = c.Err.Error()
+		}
+
+		entries = append(entries, e)
+	}
+
+	if len(entries) > 0 {
+		if n, err := r.writer.write(entries); err != nil {
+			r.tracker.CommitChanges(changes[:n])
+
+			return err
+		}
+	}
+
+	r.tracker.Commit(state)
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package syslog_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/syslog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslog_UDP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	read := func() string {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+		buf := make([]byte, 4096)
+		n, _, rErr := conn.ReadFrom(buf)
+		require.NoError(t, rErr)
+
+		return string(buf[:n])
+	}
+
+	reporter, err := syslog.New("udp", conn.LocalAddr().String(),
+		syslog.WithAppName("app"),
+		syslog.WithHostname("host"),
+		syslog.WithFacility(syslog.FacilityLocal0),
+	)
+	require.NoError(t, err)
+
+	// Passing probes seen for the first time are not logged.
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New(`bad "quote"]`))))
+
+	msg := read()
+	assert.True(t, strings.HasPrefix(msg, "<131>1 "), msg)
+	assert.Contains(t, msg, ` host app - health [health@32473 probe="db" state="unhealthy" error="bad \"quote\"\]"] probe db failed: bad "quote"]`)
+
+	// No transition, nothing is logged.
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("timeout"))))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	msg = read()
+	assert.True(t, strings.HasPrefix(msg, "<133>1 "), msg)
+	assert.Contains(t, msg, `[health@32473 probe="db" state="healthy"] probe db recovered`)
+}
+
+func TestSyslog_TCPFraming(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer func() { _ = ln.Close() }()
+
+	messages := make(chan string, 2)
+
+	go func() {
+		conn, aErr := ln.Accept()
+		if aErr != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		r := bufio.NewReader(conn)
+
+		for {
+			prefix, rErr := r.ReadString(' ')
+			if rErr != nil {
+				return
+			}
+
+			n, _ := strconv.Atoi(strings.TrimSpace(prefix))
+			buf := make([]byte, n)
+
+			if _, rErr = r.Read(buf); rErr != nil {
+				return
+			}
+
+			messages <- string(buf)
+		}
+	}()
+
+	reporter, err := syslog.New("tcp", ln.Addr().String(), syslog.WithAppName("app"))
+	require.NoError(t, err)
+
+	status := health.NewStatus().Append("cache", errors.New("timeout")).Append("db", errors.New("connection refused"))
+	require.NoError(t, reporter.Report(ctx, status))
+
+	for _, probe := range []string{"cache", "db"} {
+		select {
+		case msg := <-messages:
+			assert.True(t, strings.HasPrefix(msg, "<27>1 "), msg)
+			assert.Contains(t, msg, `probe="`+probe+`"`)
+		case <-ctx.Done():
+			t.Fatal("message not received")
+		}
+	}
+}
+
+func TestSyslog_UnsupportedNetwork(t *testing.T) {
+	_, err := syslog.New("ip", "127.0.0.1")
+	assert.Error(t, err)
+}
+
+func TestJournal_NativeProtocol(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	read := func() []byte {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+		buf := make([]byte, 4096)
+		n, rErr := conn.Read(buf)
+		require.NoError(t, rErr)
+
+		return buf[:n]
+	}
+
+	reporter := syslog.NewJournal(syslog.WithSocket(path), syslog.WithAppName("app"))
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("connection refused"))))
+
+	fields := parseJournal(t, read())
+	assert.Equal(t, "probe db failed: connection refused", fields["MESSAGE"])
+	assert.Equal(t, "3", fields["PRIORITY"])
+	assert.Equal(t, "app", fields["SYSLOG_IDENTIFIER"])
+	assert.Equal(t, "db", fields["PROBE"])
+	assert.Equal(t, "unhealthy", fields["STATE"])
+	assert.Equal(t, "connection refused", fields["ERROR"])
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", nil)))
+
+	fields = parseJournal(t, read())
+	assert.Equal(t, "5", fields["PRIORITY"])
+	assert.Equal(t, "healthy", fields["STATE"])
+	assert.NotContains(t, fields, "ERROR")
+
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("line 1\nline 2"))))
+
+	fields = parseJournal(t, read())
+	assert.Equal(t, "line 1\nline 2", fields["ERROR"])
+}
+
+func TestJournal_SkipsWrittenEntriesOnRetry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	read := func() []byte {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+		buf := make([]byte, 4096)
+		n, rErr := conn.Read(buf)
+		require.NoError(t, rErr)
+
+		return buf[:n]
+	}
+
+	reporter := syslog.NewJournal(syslog.WithSocket(path))
+
+	// The entry of "db" exceeds the maximum datagram size, so only the one
+	// of "cache" is written.
+	tooLong := errors.New(strings.Repeat("x", 16<<20))
+	status := health.NewStatus().Append("cache", errors.New("timeout")).Append("db", tooLong)
+	require.Error(t, reporter.Report(ctx, status))
+	assert.Equal(t, "cache", parseJournal(t, read())["PROBE"])
+
+	status = health.NewStatus().Append("cache", errors.New("timeout")).Append("db", errors.New("refused"))
+	require.NoError(t, reporter.Report(ctx, status))
+	assert.Equal(t, "db", parseJournal(t, read())["PROBE"])
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+
+	_, err = conn.Read(make([]byte, 4096))
+	assert.Error(t, err, "the entry of cache must not be written again")
+}
+
+func TestJournal_UnreachableSocket(t *testing.T) {
+	reporter := syslog.NewJournal(syslog.WithSocket(filepath.Join(t.TempDir(), "missing.sock")))
+	assert.Error(t, reporter.Report(context.Background(), health.NewStatus().Append("db", errors.New("timeout"))))
+}
+
+// parseJournal decodes a datagram of the journald native protocol.
+func parseJournal(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	fields := make(map[string]string)
+
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		require.GreaterOrEqual(t, i, 0)
+
+		line := string(data[:i])
+		data = data[i+1:]
+
+		if key, value, ok := strings.Cut(line, "="); ok {
+			fields[key] = value
+
+			continue
+		}
+
+		size := binary.LittleEndian.Uint64(data[:8])
+		fields[line] = string(data[8 : 8+size])
+		data = data[8+size+1:]
+	}
+
+	return fields
+}
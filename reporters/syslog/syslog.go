@@ -0,0 +1,133 @@
+package syslog
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/botchris/go-health"
+)
+
+// StructuredDataID is the SD-ID of the structured data element holding the
+// probe, state and error of syslog messages. It uses the enterprise number
+// reserved for documentation by RFC 5612.
+const StructuredDataID = "health@32473"
+
+// syslogWriter writes RFC 5424 messages to a syslog daemon.
+type syslogWriter struct {
+	network string
+	addr    string
+	opts    *options
+	conn    net.Conn
+}
+
+// New creates a new reporter which logs probe transitions to the syslog daemon
+// listening at the given address, using the RFC 5424 format. The network must
+// be "udp", "tcp" or "unixgram", for example "unixgram" and "/dev/log" for the
+// local daemon. Messages sent over TCP are framed using octet counting, as
+// described in RFC 6587.
+//
+// Probes which fail are logged with the err severity, and probes which recover
+// with the notice severity. The probe, state and error are included as the
+// structured data element StructuredDataID, for example:
+//
+//	<27>1 2024-01-01T00:00:00Z host app - health [health@32473 probe="db" state="unhealthy" error="timeout"] probe db failed: timeout
+func New(network, addr string, o ...Option) (health.Reporter, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("syslog: unsupported network %q", network)
+	}
+
+	return &reporter{
+		writer: &syslogWriter{
+			network: network,
+			addr:    addr,
+			opts:    newOptions(o),
+		},
+	}, nil
+}
+
+func (w *syslogWriter) write(entries []entry) (int, error) {
+	if w.conn == nil {
+		conn, err := net.Dial(w.network, w.addr)
+		if err != nil {
+			return 0, fmt.Errorf("syslog: connecting to %s failed: %w", w.addr, err)
+		}
+
+		w.conn = conn
+	}
+
+	for i, e := range entries {
+		msg := w.format(e)
+
+		if w.stream() {
+			msg = strconv.Itoa(len(msg)) + " " + msg
+		}
+
+		if _, err := w.conn.Write([]byte(msg)); err != nil {
+			// Reconnect on the next attempt, the connection may have been closed.
+			_ = w.conn.Close()
+			w.conn = nil
+
+			return i, fmt.Errorf("syslog: writing message failed: %w", err)
+		}
+	}
+
+	return len(entries), nil
+}
+
+// stream tells whether messages are sent over a stream connection,
+// and must be framed.
+func (w *syslogWriter) stream() bool {
+	return strings.HasPrefix(w.network, "tcp") || w.network == "unix"
+}
+
+// format renders the given entry as an RFC 5424 message.
+func (w *syslogWriter) format(e entry) string {
+	sd := fmt.Sprintf("[%s probe=\"%s\" state=\"%s\"", StructuredDataID, escapeParam(e.probe), e.state())
+	if e.err != "" {
+		sd += fmt.Sprintf(" error=\"%s\"", escapeParam(e.err))
+	}
+
+	sd += "]"
+
+	return fmt.Sprintf("<%d>1 %s %s %s - health %s %s",
+		int(w.opts.facility)*8+e.severity(),
+		time.Now().UTC().Format(time.RFC3339Nano),
+		headerField(w.opts.hostname, 255),
+		headerField(w.opts.appName, 48),
+		sd,
+		e.message(),
+	)
+}
+
+// headerField returns the given value as a valid header field,
+// or the NILVALUE if empty.
+func headerField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	if value == "" {
+		return "-"
+	}
+
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+
+	return value
+}
+
+// escapeParam escapes the characters which are not allowed
+// in structured data parameter values.
+func escapeParam(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,17 @@
+package systemd
+
+// Option is a functional option for the systemd reporter.
+type Option func(*options)
+
+type options struct {
+	socket string
+}
+
+// WithSocket sets the path of the notification socket, overriding the
+// NOTIFY_SOCKET environment variable. Abstract sockets can be given
+// prefixed with "@".
+func WithSocket(path string) Option {
+	return func(o *options) {
+		o.socket = path
+	}
+}
@@ -0,0 +1,98 @@
+package systemd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/summary"
+)
+
+type notifier struct {
+	opts *options
+
+	ready bool
+	mu    sync.Mutex
+}
+
+// New creates a new reporter which notifies systemd about the health of the
+// service using the sd_notify protocol, for services configured with
+// Type=notify and, optionally, WatchdogSec:
+//
+//   - READY=1 is sent once, the first time every probe passes.
+//   - STATUS= is sent on every report with a summary of the probes.
+//   - WATCHDOG=1 is sent on every report while every probe passes.
+//
+// As the watchdog is only notified when the Checker reports a status,
+// WatchdogSec should be longer than the time the Checker needs to report
+// a healthy status, see health.WithPeriod and health.WithSuccessThreshold.
+//
+// The notification socket is read from the NOTIFY_SOCKET environment
+// variable, unless set using WithSocket. If no socket is set, for example
+// when not running under systemd, the reporter does nothing.
+func New(o ...Option) health.Reporter {
+	opts := &options{socket: os.Getenv("NOTIFY_SOCKET")}
+
+	for i := range o {
+		o[i](opts)
+	}
+
+	return &notifier{opts: opts}
+}
+
+func (n *notifier) Report(_ context.Context, status health.Status) error {
+	if n.opts.socket == "" {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	healthy := status.AsError() == nil
+	lines := make([]string, 0, 3)
+
+	if healthy && !n.ready {
+		lines = append(lines, "READY=1")
+	}
+
+	lines = append(lines, "STATUS="+summary.Line(status, false))
+
+	if healthy {
+		lines = append(lines, "WATCHDOG=1")
+	}
+
+	if err := n.notify(strings.Join(lines, "\n")); err != nil {
+		return fmt.Errorf("systemd reporter: %w", err)
+	}
+
+	n.ready = n.ready || healthy
+
+	return nil
+}
+
+// notify sends the given state as a single datagram to the notification socket.
+func (n *notifier) notify(state string) error {
+	addr := &net.UnixAddr{Name: n.opts.socket, Net: "unixgram"}
+
+	// Abstract sockets are given with a leading "@".
+	if strings.HasPrefix(addr.Name, "@") {
+		addr.Name = "\x00" + addr.Name[1:]
+	}
+
+	conn, err := net.DialUnix(addr.Net, nil, addr)
+	if err != nil {
+		return fmt.Errorf("connecting to notification socket failed: %w", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("writing to notification socket failed: %w", err)
+	}
+
+	return nil
+}
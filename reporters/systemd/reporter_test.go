@@ -0,0 +1,64 @@
+package systemd_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/systemd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Protocol(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	read := func() string {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+
+		buf := make([]byte, 4096)
+		n, rErr := conn.Read(buf)
+		require.NoError(t, rErr)
+
+		return string(buf[:n])
+	}
+
+	reporter := systemd.New(systemd.WithSocket(path))
+	healthy := health.NewStatus().Append("db", nil).Append("cache", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	assert.Equal(t, "STATUS=1/2 probes failing: db", read())
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+	assert.Equal(t, "READY=1\nSTATUS=2/2 probes passing\nWATCHDOG=1", read())
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+	assert.Equal(t, "STATUS=2/2 probes passing\nWATCHDOG=1", read())
+
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	assert.Equal(t, "STATUS=1/2 probes failing: db", read())
+}
+
+func TestNotifier_NoSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+
+	reporter := systemd.New()
+	assert.NoError(t, reporter.Report(context.Background(), health.NewStatus().Append("db", nil)))
+}
+
+func TestNotifier_UnreachableSocket(t *testing.T) {
+	reporter := systemd.New(systemd.WithSocket(filepath.Join(t.TempDir(), "missing.sock")))
+	assert.Error(t, reporter.Report(context.Background(), health.NewStatus().Append("db", nil)))
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"text/template"
+)
+
+// Option is a functional option for the webhook reporter.
+type Option func(*options) error
+
+type options struct {
+	client      *http.Client
+	template    *template.Template
+	contentType string
+	headers     http.Header
+	secret      []byte
+}
+
+// WithTemplate sets a Go text/template used to render the request body.
+// The template is executed with an Event as data, and provides a "json"
+// function to encode values as JSON, which is useful to escape strings.
+// See SlackTemplate and TeamsTemplate for examples.
+//
+// If no template is provided, the Event is encoded as JSON.
+func WithTemplate(text string) Option {
+	return func(o *options) error {
+		t, err := template.New("webhook").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return fmt.Errorf("parsing template failed: %w", err)
+		}
+
+		o.template = t
+
+		return nil
+	}
+}
+
+// WithContentType sets the Content-Type header of the requests.
+// Defaults to "application/json".
+func WithContentType(contentType string) Option {
+	return func(o *options) error {
+		o.contentType = contentType
+
+		return nil
+	}
+}
+
+// WithHeader adds a header to every request, for example to
+// provide an API key.
+func WithHeader(key, value string) Option {
+	return func(o *options) error {
+		o.headers.Add(key, value)
+
+		return nil
+	}
+}
+
+// WithHMACSecret signs every request with HMAC-SHA256 using the given
+// secret. The hex encoded signature of the body is sent in the
+// "X-Signature-256" header, prefixed with "sha256=".
+func WithHMACSecret(secret []byte) Option {
+	return func(o *options) error {
+		if len(secret) == 0 {
+			return errors.New("hmac secret cannot be empty")
+		}
+
+		o.secret = secret
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send the requests.
+// Defaults to a client with a 10 seconds timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) error {
+		if client == nil {
+			return errors.New("http client cannot be nil")
+		}
+
+		o.client = client
+
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/internal/httpretry"
+	"github.com/botchris/go-health/internal/transition"
+	"github.com/cenkalti/backoff/v4"
+)
+
+// SignatureHeader is the header holding the HMAC-SHA256 signature of the
+// request body when a secret is set using WithHMACSecret.
+const SignatureHeader = "X-Signature-256"
+
+// Event is the data sent to the webhook on every status transition.
+type Event struct {
+	// Time is the time the transition was detected.
+	Time time.Time `json:"time"`
+
+	// Healthy tells whether every probe is passing.
+	Healthy bool `json:"healthy"`
+
+	// Summary is a human-readable description of the transition.
+	Summary string `json:"summary"`
+
+	// Probes holds the result of every probe, sorted by name.
+	Probes []Probe `json:"probes"`
+
+	// Changed holds the result of the probes whose state changed
+	// since the last delivered event, sorted by name.
+	Changed []Probe `json:"changed"`
+}
+
+// Probe is the result of a single probe within an Event.
+type Probe struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+
+	// Duration is the latency of the probe, in milliseconds.
+	Duration float64 `json:"durationMs"`
+}
+
+type webhook struct {
+	url  string
+	opts *options
+
+	tracker transition.Tracker
+	mu      sync.Mutex
+}
+
+// New creates a new reporter which sends an HTTP POST request to the given
+// URL every time a probe transitions between passing and failing. Probes
+// seen for the first time are only considered a transition if failing, so
+// the first reported status is only sent if any probe is failing.
+//
+// Requests failing with a network error, a 5xx, 408 or 429 response are
+// retried by the Checker until its reporter timeout expires, see
+// health.WithReporterTimeout.
+func New(url string, o ...Option) (health.Reporter, error) {
+	opts := &options{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		contentType: "application/json",
+		headers:     make(http.Header),
+	}
+
+	for i := range o {
+		if err := o[i](opts); err != nil {
+			return nil, fmt.Errorf("webhook: applying option %d failed: %w", i, err)
+		}
+	}
+
+	return &webhook{url: url, opts: opts}, nil
+}
+
+func (r *webhook) Report(ctx context.Context, status health.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, state := r.transition(status)
+	if event == nil {
+		return nil
+	}
+
+	body, err := r.render(event)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("webhook: rendering body failed: %w", err))
+	}
+
+	if err := r.send(ctx, body); err != nil {
+		return err
+	}
+
+	r.tracker.Commit(state)
+
+	return nil
+}
+
+// transition returns the event to be sent for the given status, or nil if
+// no probe changed its state since the last delivered event. It also returns
+// the state to be committed once the event is delivered.
+func (r *webhook) transition(status health.Status) (*Event, transition.State) {
+	changes, state := r.tracker.Diff(status)
+	if len(changes) == 0 {
+		return nil, state
+	}
+
+	errs := status.Errors()
+	durations := health.Durations(status)
+	event := &Event{
+		Time:    time.Now(),
+		Healthy: status.AsError() == nil,
+	}
+
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	probes := make(map[string]Probe, len(names))
+
+	for _, name := range names {
+		p := Probe{
+			Name:     name,
+			Healthy:  errs[name] == nil,
+			Duration: float64(durations[name].Microseconds()) / 1000,
+		}
+		if errs[name] != nil {
+			p.Error = errs[name].Error()
+		}
+
+		probes[name] = p
+		event.Probes = append(event.Probes, p)
+	}
+
+	for _, c := range changes {
+		event.Changed = append(event.Changed, probes[c.Probe])
+	}
+
+	event.Summary = summary(event)
+
+	return event, state
+}
+
+func (r *webhook) render(event *Event) ([]byte, error) {
+	if r.opts.template == nil {
+		return json.Marshal(event)
+	}
+
+	buf := bytes.Buffer{}
+	if err := r.opts.template.Execute(&buf, event); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (r *webhook) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("webhook: creating request failed: %w", err))
+	}
+
+	for key, values := range r.opts.headers {
+		req.Header[key] = values
+	}
+
+	req.Header.Set("Content-Type", r.opts.contentType)
+
+	if len(r.opts.secret) > 0 {
+		mac := hmac.New(sha256.New, r.opts.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := r.opts.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: sending request failed: %w", err)
+	}
+
+	if err := httpretry.Check(res); err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+
+	return nil
+}
+
+func summary(event *Event) string {
+	if event.Healthy {
+		return "All health checks are passing"
+	}
+
+	failing := 0
+
+	for i := range event.Probes {
+		if !event.Probes[i].Healthy {
+			failing++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d health checks are failing", failing, len(event.Probes))
+}
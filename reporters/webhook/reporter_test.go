@@ -0,0 +1,195 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/botchris/go-health/reporters/webhook"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_SendsTransitions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hook := &recorder{}
+	srv := httptest.NewServer(hook)
+
+	defer srv.Close()
+
+	reporter, err := webhook.New(srv.URL, webhook.WithHeader("X-Api-Key", "key"))
+	require.NoError(t, err)
+
+	healthy := health.NewStatus().Append("db", nil).Append("cache", nil)
+	unhealthy := health.NewStatus().Append("db", errors.New("connection refused")).Append("cache", nil)
+
+	require.NoError(t, reporter.Report(ctx, healthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, unhealthy))
+	require.NoError(t, reporter.Report(ctx, healthy))
+
+	requests := hook.all()
+	require.Len(t, requests, 2, "only transitions must be sent")
+
+	assert.Equal(t, "application/json", requests[0].header.Get("Content-Type"))
+	assert.Equal(t, "key", requests[0].header.Get("X-Api-Key"))
+
+	first := webhook.Event{}
+	require.NoError(t, json.Unmarshal(requests[0].body, &first))
+	assert.False(t, first.Healthy)
+	assert.Equal(t, "1 of 2 health checks are failing", first.Summary)
+	assert.Len(t, first.Probes, 2)
+	require.Len(t, first.Changed, 1)
+	assert.Equal(t, "db", first.Changed[0].Name)
+	assert.Equal(t, "connection refused", first.Changed[0].Error)
+
+	second := webhook.Event{}
+	require.NoError(t, json.Unmarshal(requests[1].body, &second))
+	assert.True(t, second.Healthy)
+	require.Len(t, second.Changed, 1)
+	assert.True(t, second.Changed[0].Healthy)
+}
+
+func TestWebhook_Templates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	unhealthy := health.NewStatus().Append("db", errors.New(`refused "quoted"`))
+
+	for name, tmpl := range map[string]string{"slack": webhook.SlackTemplate, "teams": webhook.TeamsTemplate} {
+		t.Run(name, func(t *testing.T) {
+			hook := &recorder{}
+			srv := httptest.NewServer(hook)
+
+			defer srv.Close()
+
+			reporter, err := webhook.New(srv.URL, webhook.WithTemplate(tmpl))
+			require.NoError(t, err)
+			require.NoError(t, reporter.Report(ctx, unhealthy))
+
+			requests := hook.all()
+			require.Len(t, requests, 1)
+			assert.True(t, json.Valid(requests[0].body), string(requests[0].body))
+			assert.Contains(t, string(requests[0].body), `refused \"quoted\"`)
+		})
+	}
+
+	_, err := webhook.New("http://localhost", webhook.WithTemplate("{{ .Unclosed "))
+	assert.Error(t, err)
+}
+
+func TestWebhook_HMACSignature(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hook := &recorder{}
+	srv := httptest.NewServer(hook)
+
+	defer srv.Close()
+
+	secret := []byte("s3cr3t")
+	reporter, err := webhook.New(srv.URL, webhook.WithHMACSecret(secret))
+	require.NoError(t, err)
+	require.NoError(t, reporter.Report(ctx, health.NewStatus().Append("db", errors.New("refused"))))
+
+	requests := hook.all()
+	require.Len(t, requests, 1)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(requests[0].body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), requests[0].header.Get(webhook.SignatureHeader))
+}
+
+func TestWebhook_Errors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	unhealthy := health.NewStatus().Append("db", errors.New("refused"))
+
+	t.Run("server errors are retried until delivered", func(t *testing.T) {
+		hook := &recorder{codes: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+		srv := httptest.NewServer(hook)
+
+		defer srv.Close()
+
+		reporter, err := webhook.New(srv.URL)
+		require.NoError(t, err)
+
+		rErr := backoff.Retry(
+			func() error { return reporter.Report(ctx, unhealthy) },
+			backoff.WithContext(backoff.NewConstantBackOff(time.Millisecond), ctx),
+		)
+		require.NoError(t, rErr)
+		assert.Len(t, hook.all(), 3)
+
+		// The transition was delivered, so it is not sent again.
+		require.NoError(t, reporter.Report(ctx, unhealthy))
+		assert.Len(t, hook.all(), 3)
+	})
+
+	t.Run("client errors are permanent", func(t *testing.T) {
+		hook := &recorder{codes: []int{http.StatusBadRequest}}
+		srv := httptest.NewServer(hook)
+
+		defer srv.Close()
+
+		reporter, err := webhook.New(srv.URL)
+		require.NoError(t, err)
+
+		rErr := backoff.Retry(
+			func() error { return reporter.Report(ctx, unhealthy) },
+			backoff.WithContext(backoff.NewConstantBackOff(time.Millisecond), ctx),
+		)
+		require.Error(t, rErr)
+		assert.Len(t, hook.all(), 1)
+	})
+}
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// recorder records the received requests, answering them with the given
+// status codes in order, and 204 No Content afterwards.
+type recorder struct {
+	codes    []int
+	requests []request
+	mu       sync.Mutex
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, request{header: req.Header, body: body})
+
+	code := http.StatusNoContent
+	if len(r.codes) > 0 {
+		code, r.codes = r.codes[0], r.codes[1:]
+	}
+
+	w.WriteHeader(code)
+}
+
+func (r *recorder) all() []request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]request(nil), r.requests...)
+}
@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"encoding/json"
+	"text/template"
+)
+
+// SlackTemplate renders a message for Slack incoming webhooks.
+const SlackTemplate = `{"text": {{ json .Summary }}, "blocks": [
+{"type": "section", "text": {"type": "mrkdwn", "text": {{ json (printf "*%s*" .Summary) }}}}
+{{- range .Changed }},
+{"type": "section", "text": {"type": "mrkdwn", "text": {{ if .Healthy }}{{ json (printf ":white_check_mark: *%s* recovered" .Name) }}{{ else }}{{ json (printf ":x: *%s* failed: %s" .Name .Error) }}{{ end }}}}
+{{- end }}
+]}`
+
+// TeamsTemplate renders a message card for Microsoft Teams incoming webhooks.
+const TeamsTemplate = `{
+"@type": "MessageCard",
+"@context": "https://schema.org/extensions",
+"themeColor": "{{ if .Healthy }}2E7D32{{ else }}C62828{{ end }}",
+"summary": {{ json .Summary }},
+"title": {{ json .Summary }},
+"sections": [{"facts": [
+{{- range $i, $p := .Changed }}{{ if $i }},{{ end }}
+{"name": {{ json $p.Name }}, "value": {{ if $p.Healthy }}"recovered"{{ else }}{{ json (printf "failed: %s" $p.Error) }}{{ end }}}
+{{- end }}
+]}]
+}`
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+
+		return string(b), err
+	},
+}
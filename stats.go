@@ -0,0 +1,210 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Stats is a snapshot of the internal state of a Checker, useful to
+// troubleshoot a Checker which seems stuck. See Checker.Stats.
+type Stats struct {
+	// Period, InitialDelay, SuccessThreshold, FailureThreshold,
+	// ReporterTimeout and BufferSize are the options of the Checker.
+	Period           time.Duration
+	InitialDelay     time.Duration
+	SuccessThreshold int
+	FailureThreshold int
+	ReporterTimeout  time.Duration
+	BufferSize       int
+
+	// ConsecutiveSuccesses and ConsecutiveFailures are the counters compared
+	// against the thresholds to decide whether a Status is notified.
+	ConsecutiveSuccesses int
+	ConsecutiveFailures  int
+
+	// Checks is the number of completed checks, and LastCheck the time the
+	// last one completed, zero if none.
+	Checks    uint64
+	LastCheck time.Time
+
+	// Notifications is the number of Status notified to watchers.
+	Notifications uint64
+
+	// Watchers is the number of watchers, including the one used to
+	// forward statuses to reporters.
+	Watchers int
+
+	// DroppedUpdates is the number of Status not delivered to a watcher
+	// because its buffer was full.
+	DroppedUpdates uint64
+
+	// Probes holds the state of every registered probe, sorted by name.
+	Probes []ProbeStats
+
+	// Reporters holds the state of every registered reporter,
+	// in registration order.
+	Reporters []ReporterStats
+
+	// Overrides holds the active overrides, sorted by probe name.
+	Overrides []Override
+}
+
+// ProbeStats is the state of a single probe within Stats.
+type ProbeStats struct {
+	Name    string
+	Timeout time.Duration
+
+	// LastCheck is the time of the last check including this probe,
+	// zero if the probe was not checked yet.
+	LastCheck time.Time
+
+	// LastError is the error returned by the last check, nil on success.
+	LastError error
+
+	// LastDuration is the time the last check of the probe took.
+	LastDuration time.Duration
+}
+
+// ReporterStats is the state of a single reporter within Stats.
+type ReporterStats struct {
+	// Type is the Go type of the reporter, for example "*webhook.webhook".
+	Type string
+
+	// Reports is the number of statuses successfully reported.
+	Reports uint64
+
+	// Failures is the number of statuses which could not be reported
+	// before the reporter timeout expired.
+	Failures uint64
+
+	// Retrying tells whether a report is in progress, after at least
+	// one failed attempt.
+	Retrying bool
+
+	// LastError is the error of the last failed attempt, and LastErrorTime
+	// its time, zero if no attempt ever failed.
+	LastError     error
+	LastErrorTime time.Time
+}
+
+// probeStats is the state recorded for a probe.
+type probeStats struct {
+	lastCheck    time.Time
+	lastError    error
+	lastDuration time.Duration
+}
+
+// reporterEntry is a registered reporter and its recorded state.
+type reporterEntry struct {
+	reporter Reporter
+	stats    ReporterStats
+}
+
+// Stats returns a snapshot of the internal state of the Checker.
+func (ch *Checker) Stats() Stats {
+	st := Stats{
+		Period:           ch.opts.period,
+		InitialDelay:     ch.opts.initialDelay,
+		SuccessThreshold: ch.opts.successThreshold,
+		FailureThreshold: ch.opts.failureThreshold,
+		ReporterTimeout:  ch.opts.reporterTimeout,
+		BufferSize:       ch.opts.bufferSize,
+	}
+
+	ch.watchersMu.Lock()
+	st.Watchers = len(ch.watchers)
+	ch.watchersMu.Unlock()
+
+	ch.chMu.RLock()
+	probes := make([]ProbeStats, 0, len(ch.probes))
+
+	for name, pc := range ch.probes {
+		probes = append(probes, ProbeStats{Name: name, Timeout: pc.timeout})
+	}
+
+	reporters := make([]*reporterEntry, len(ch.reporters))
+	copy(reporters, ch.reporters)
+	ch.chMu.RUnlock()
+
+	sort.Slice(probes, func(i, j int) bool { return probes[i].Name < probes[j].Name })
+
+	ch.statsMu.Lock()
+	defer ch.statsMu.Unlock()
+
+	st.ConsecutiveSuccesses = ch.consecutiveSuccesses
+	st.ConsecutiveFailures = ch.consecutiveFailures
+	st.Checks = ch.checks
+	st.LastCheck = ch.lastCheck
+	st.Notifications = ch.notifications
+	st.DroppedUpdates = ch.droppedUpdates
+
+	for i := range probes {
+		if ps, ok := ch.probeStats[probes[i].Name]; ok {
+			probes[i].LastCheck = ps.lastCheck
+			probes[i].LastError = ps.lastError
+			probes[i].LastDuration = ps.lastDuration
+		}
+	}
+
+	st.Probes = probes
+	st.Reporters = make([]ReporterStats, 0, len(reporters))
+
+	for _, entry := range reporters {
+		st.Reporters = append(st.Reporters, entry.stats)
+	}
+
+	st.Overrides = ch.Overrides()
+
+	return st
+}
+
+// recordCheck records the result of every probe of the given Status.
+func (ch *Checker) recordCheck(st Status) {
+	now := time.Now()
+	durations := Durations(st)
+
+	ch.statsMu.Lock()
+	defer ch.statsMu.Unlock()
+
+	ch.checks++
+	ch.lastCheck = now
+
+	for name, err := range st.Errors() {
+		ch.probeStats[name] = probeStats{
+			lastCheck:    now,
+			lastError:    err,
+			lastDuration: durations[name],
+		}
+	}
+}
+
+// recordAttempt records the result of a single attempt of a reporter.
+func (ch *Checker) recordAttempt(entry *reporterEntry, err error) {
+	ch.statsMu.Lock()
+	defer ch.statsMu.Unlock()
+
+	if err == nil {
+		entry.stats.Reports++
+		entry.stats.Retrying = false
+
+		return
+	}
+
+	entry.stats.Retrying = true
+	entry.stats.LastError = err
+	entry.stats.LastErrorTime = time.Now()
+}
+
+// recordFailure records that a reporter gave up reporting a Status.
+func (ch *Checker) recordFailure(entry *reporterEntry) {
+	ch.statsMu.Lock()
+	defer ch.statsMu.Unlock()
+
+	entry.stats.Failures++
+	entry.stats.Retrying = false
+}
+
+func newReporterEntry(r Reporter) *reporterEntry {
+	return &reporterEntry{reporter: r, stats: ReporterStats{Type: fmt.Sprintf("%T", r)}}
+}
@@ -34,12 +34,74 @@ type Status interface {
 	Duration() time.Duration
 }
 
+// DurationsStatus is implemented by Status values which record the latency
+// of each Probe, such as the ones created by NewStatus. It is kept apart from
+// Status so existing implementations of Status remain valid, see Durations.
+type DurationsStatus interface {
+	Status
+
+	// Durations returns a map of Probe names to the time elapsed since
+	// this Status was created until their result was appended. As probes
+	// are executed concurrently, this is the latency of each Probe.
+	Durations() map[string]time.Duration
+}
+
+// Durations returns the latency of each Probe of the given Status, or nil
+// if it does not implement DurationsStatus.
+func Durations(st Status) map[string]time.Duration {
+	if ds, ok := st.(DurationsStatus); ok {
+		return ds.Durations()
+	}
+
+	return nil
+}
+
+// OverridesStatus is implemented by Status values which record the manual
+// overrides applied to their results, such as the ones created by NewStatus.
+// It is kept apart from Status so existing implementations of Status remain
+// valid, see Overrides and AppendOverridden.
+type OverridesStatus interface {
+	Status
+
+	// Overrides returns a map of Probe names to the manual override applied
+	// to their result, see Checker.SetOverride. Probes whose result was not
+	// overridden are not present.
+	Overrides() map[string]Override
+
+	// AppendOverridden adds the result of a probe whose result was
+	// replaced by the given override.
+	AppendOverridden(probeName string, result error, o Override) Status
+}
+
+// Overrides returns the manual overrides applied to the results of the given
+// Status, or nil if it does not implement OverridesStatus.
+func Overrides(st Status) map[string]Override {
+	if ost, ok := st.(OverridesStatus); ok {
+		return ost.Overrides()
+	}
+
+	return nil
+}
+
+// AppendOverridden adds the result of a probe whose result was replaced by
+// the given override to the given Status. If the Status does not implement
+// OverridesStatus, only the result is added.
+func AppendOverridden(st Status, probeName string, result error, o Override) Status {
+	if ost, ok := st.(OverridesStatus); ok {
+		return ost.AppendOverridden(probeName, result, o)
+	}
+
+	return st.Append(probeName, result)
+}
+
 type status struct {
-	errors   map[string]error
-	flatten  []error
-	duration time.Duration
-	started  time.Time
-	mu       sync.RWMutex
+	errors    map[string]error
+	flatten   []error
+	duration  time.Duration
+	durations map[string]time.Duration
+	overrides map[string]Override
+	started   time.Time
+	mu        sync.RWMutex
 }
 
 // NewStatus creates and returns a new Status instance.
@@ -53,10 +115,16 @@ func NewStatus(now ...time.Time) Status {
 		n = now[0]
 	}
 
+	return newStatus(n)
+}
+
+func newStatus(n time.Time) *status {
 	return &status{
-		errors:  make(map[string]error),
-		flatten: make([]error, 0),
-		started: n,
+		errors:    make(map[string]error),
+		flatten:   make([]error, 0),
+		durations: make(map[string]time.Duration),
+		overrides: make(map[string]Override),
+		started:   n,
 	}
 }
 
@@ -66,6 +134,7 @@ func (s *status) Append(probeName string, result error) Status {
 
 	s.errors[probeName] = result
 	s.duration = time.Since(s.started)
+	s.durations[probeName] = s.duration
 
 	if result != nil {
 		s.flatten = append(s.flatten, result)
@@ -95,6 +164,45 @@ func (s *status) Duration() time.Duration {
 	return s.duration
 }
 
+// Durations returns a copy of the latency of each Probe.
+func (s *status) Durations() map[string]time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]time.Duration, len(s.durations))
+
+	for name, d := range s.durations {
+		out[name] = d
+	}
+
+	return out
+}
+
+// Overrides returns a copy of the overrides applied to the probe results.
+func (s *status) Overrides() map[string]Override {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]Override, len(s.overrides))
+
+	for name, o := range s.overrides {
+		out[name] = o
+	}
+
+	return out
+}
+
+func (s *status) AppendOverridden(probeName string, result error, o Override) Status {
+	s.Append(probeName, result)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides[probeName] = o
+
+	return s
+}
+
 // AsError aggregates all errors in the StatusStruct and returns them
 // as a single error using errors.Join. If there are no errors,
 // it returns nil.
@@ -108,3 +216,41 @@ func (s *status) AsError() error {
 
 	return fmt.Errorf("health check failed with %d errors: %w", len(s.flatten), errors.Join(s.flatten...))
 }
+
+// Filter returns a new Status containing only the results of the probes
+// for which keep returns true. Probe durations and overrides are preserved,
+// and the total duration is the one of the given Status.
+func Filter(st Status, keep func(probeName string) bool) Status {
+	durations := Durations(st)
+	overrides := Overrides(st)
+	out := &status{
+		errors:    make(map[string]error),
+		flatten:   make([]error, 0),
+		duration:  st.Duration(),
+		durations: make(map[string]time.Duration),
+		overrides: make(map[string]Override),
+		started:   time.Now().Add(-st.Duration()),
+	}
+
+	for name, err := range st.Errors() {
+		if !keep(name) {
+			continue
+		}
+
+		out.errors[name] = err
+
+		if d, ok := durations[name]; ok {
+			out.durations[name] = d
+		}
+
+		if o, ok := overrides[name]; ok {
+			out.overrides[name] = o
+		}
+
+		if err != nil {
+			out.flatten = append(out.flatten, err)
+		}
+	}
+
+	return out
+}
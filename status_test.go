@@ -0,0 +1,61 @@
+package health_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/botchris/go-health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	st := health.NewStatus().Append("db", sentinel).Append("cache", nil).Append("queue", nil)
+
+	filtered := health.Filter(st, func(name string) bool { return name != "queue" })
+
+	require.Len(t, filtered.Errors(), 2)
+	assert.Contains(t, filtered.Errors(), "db")
+	assert.Contains(t, filtered.Errors(), "cache")
+	assert.Contains(t, health.Durations(filtered), "db")
+	assert.Equal(t, st.Duration(), filtered.Duration())
+	assert.ErrorIs(t, filtered.AsError(), sentinel)
+
+	healthy := health.Filter(st, func(name string) bool { return name != "db" })
+	assert.NoError(t, healthy.AsError())
+	assert.Len(t, st.Errors(), 3, "original status must not be modified")
+}
+
+func TestDurations(t *testing.T) {
+	st := health.NewStatus().Append("db", nil)
+
+	durations := health.Durations(st)
+	require.Contains(t, durations, "db")
+
+	delete(durations, "db")
+	assert.Contains(t, health.Durations(st), "db", "returned map must be a copy")
+
+	assert.Nil(t, health.Durations(plainStatus{Status: st}))
+}
+
+func TestOverrides(t *testing.T) {
+	o := health.Override{Reason: "maintenance", Until: time.Now().Add(time.Minute)}
+	st := health.AppendOverridden(health.NewStatus(), "db", &health.OverrideError{Override: o}, o)
+
+	overrides := health.Overrides(st)
+	require.Contains(t, overrides, "db")
+
+	delete(overrides, "db")
+	assert.Contains(t, health.Overrides(st), "db", "returned map must be a copy")
+
+	plain := plainStatus{Status: health.NewStatus()}
+	assert.Nil(t, health.Overrides(plain))
+	assert.Contains(t, health.AppendOverridden(plain, "db", nil, o).Errors(), "db")
+}
+
+// plainStatus is a Status which does not record probe latencies.
+type plainStatus struct {
+	health.Status
+}